@@ -0,0 +1,84 @@
+package file
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// anonymousFile is the writer of a File created by NewAnonymousFile. When the
+// platform supports unnamed files tmpName is empty, otherwise it holds the name
+// of the fallback temp file that is removed on Close.
+type anonymousFile struct {
+	*os.File
+	tmpName string
+}
+
+func (a *anonymousFile) Close() error {
+	err := a.File.Close()
+	if a.tmpName != "" {
+		if err2 := os.Remove(a.tmpName); err2 != nil && !os.IsNotExist(err2) {
+			err = errors.Join(err, err2)
+		}
+	}
+	return err
+}
+
+func (a *anonymousFile) link(path string) error {
+	if a.tmpName != "" {
+		return os.Link(a.tmpName, path)
+	}
+	return linkUnnamed(a.File, path)
+}
+
+func createTempAnonymous(dir string) (*anonymousFile, error) {
+	fh, err := os.CreateTemp(dir, ".anonymous-*")
+	if err != nil {
+		return nil, err
+	}
+	return &anonymousFile{File: fh, tmpName: fh.Name()}, nil
+}
+
+// NewAnonymousFile creates a file in dir that has no name in the directory and
+// is deleted automatically on Close unless it was given a name via Materialize.
+// On Linux it uses O_TMPFILE, other platforms fall back to a temp file that is
+// removed on Close.
+func NewAnonymousFile(dir string) (*File, error) {
+	a, err := openAnonymous(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create anonymous file in %q: %w", dir, err)
+	}
+	load := func() (io.Reader, error) {
+		return io.NewSectionReader(a.File, 0, math.MaxInt64), nil
+	}
+	return &File{
+		Writer: &Writer{Directory: dir, Writer: a},
+		reader: sync.OnceValues(load),
+	}, nil
+}
+
+// Materialize links an anonymous file into the file system at path.
+// The content stays accessible through f and remains at path after Close.
+func (f *File) Materialize(path string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Writer == nil {
+		return errors.New("file is not an anonymous file")
+	}
+	a, ok := f.Writer.Writer.(*anonymousFile)
+	if !ok {
+		return fmt.Errorf("file %q is not an anonymous file", f.FilePath)
+	}
+	if err := a.link(path); err != nil {
+		return fmt.Errorf("failed to materialize anonymous file at %q: %w", path, err)
+	}
+	f.FilePath = path
+	f.Writer.Directory = filepath.Dir(path)
+	f.Writer.FileName = filepath.Base(path)
+	f.Writer.FilePath = path
+	return nil
+}
@@ -0,0 +1,32 @@
+//go:build linux
+
+package file
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"golang.org/x/sys/unix"
+)
+
+func openAnonymous(dir string) (*anonymousFile, error) {
+	fd, err := unix.Open(dir, unix.O_TMPFILE|unix.O_RDWR|unix.O_CLOEXEC, 0o600)
+	if err != nil {
+		// Not every file system supports O_TMPFILE.
+		if errors.Is(err, unix.EOPNOTSUPP) || errors.Is(err, unix.EISDIR) {
+			return createTempAnonymous(dir)
+		}
+		return nil, &os.PathError{Op: "open", Path: dir, Err: err}
+	}
+	return &anonymousFile{File: os.NewFile(uintptr(fd), filepath.Join(dir, "(anonymous)"))}, nil
+}
+
+func linkUnnamed(fh *os.File, path string) error {
+	src := "/proc/self/fd/" + strconv.Itoa(int(fh.Fd()))
+	if err := unix.Linkat(unix.AT_FDCWD, src, unix.AT_FDCWD, path, unix.AT_SYMLINK_FOLLOW); err != nil {
+		return &os.LinkError{Op: "linkat", Old: src, New: path, Err: err}
+	}
+	return nil
+}
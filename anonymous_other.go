@@ -0,0 +1,16 @@
+//go:build !linux
+
+package file
+
+import (
+	"errors"
+	"os"
+)
+
+func openAnonymous(dir string) (*anonymousFile, error) {
+	return createTempAnonymous(dir)
+}
+
+func linkUnnamed(_ *os.File, _ string) error {
+	return errors.ErrUnsupported
+}
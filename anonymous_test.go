@@ -0,0 +1,78 @@
+package file_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// @markdown
+// TestNewAnonymousFile illustrates how to use an unnamed scratch file that is removed on Close.
+func TestNewAnonymousFile(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+
+	f, err := file.NewAnonymousFile(dir)
+	require.NoError(t, err)
+
+	_, err = f.Write([]byte("Hello, World!"))
+	require.NoError(t, err)
+
+	cnt, err := f.Read()
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, World!", string(cnt))
+
+	err = f.Close()
+	require.NoError(t, err)
+
+	// Nothing is left behind in the directory
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestAnonymousFileMaterialize(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	target := filepath.Join(dir, "materialized.txt")
+
+	f, err := file.NewAnonymousFile(dir)
+	require.NoError(t, err)
+
+	_, err = f.Write([]byte("Hello, World!"))
+	require.NoError(t, err)
+
+	err = f.Materialize(target)
+	require.NoError(t, err)
+	assert.Equal(t, target, f.FilePath)
+
+	err = f.Close()
+	require.NoError(t, err)
+
+	cnt, err := os.ReadFile(target)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, World!", string(cnt))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestMaterializeNonAnonymousFile(t *testing.T) {
+	t.Parallel()
+	f := file.New(filepath.Join(t.TempDir(), "file.txt"))
+
+	err := f.Materialize(filepath.Join(t.TempDir(), "other.txt"))
+	assert.ErrorContains(t, err, "not an anonymous file")
+}
+
+func TestNewAnonymousFileMissingDirectory(t *testing.T) {
+	t.Parallel()
+	_, err := file.NewAnonymousFile(filepath.Join(t.TempDir(), "missing"))
+	assert.ErrorContains(t, err, "failed to create anonymous file")
+}
@@ -0,0 +1,221 @@
+package file
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+)
+
+// createTemp creates the sibling temp file used to atomically replace filePath
+// and returns it with its name. The temp file is created inside the root if one
+// is configured. It gets the permissions of the file that gets replaced or, for a
+// new file, the same permissions NewWriter creates it with.
+func (c *config) createTemp(filePath string) (*os.File, string, error) {
+	if err := c.mkdirParent(filePath); err != nil {
+		return nil, "", err
+	}
+	prefix := filepath.Join(filepath.Dir(filePath), "."+filepath.Base(filePath)+".tmp-")
+	var (
+		tmp  *os.File
+		name string
+		err  error
+	)
+	for range 10000 {
+		name = prefix + strconv.FormatUint(rand.Uint64(), 36)
+		tmp, err = c.openFile(name, os.O_RDWR|os.O_CREATE|os.O_EXCL, c.filePerm())
+		if !os.IsExist(err) {
+			break
+		}
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+	// Keep the permissions of the file that gets replaced.
+	if info, err := c.stat(filePath); err == nil {
+		if err := tmp.Chmod(info.Mode().Perm()); err != nil {
+			return nil, "", errors.Join(fmt.Errorf("failed to chmod temp file: %w", err), tmp.Close(), c.remove(name))
+		}
+	}
+	return tmp, name, nil
+}
+
+// writeAtomic writes content to a temp file next to filePath and renames it over
+// filePath. The check is run right before the rename and aborts the write when it
+// returns an error, leaving filePath untouched.
+func (c *config) writeAtomic(filePath string, content []byte, check func() error) error {
+	write := func(w io.Writer) error {
+		_, err := w.Write(content)
+		return err
+	}
+	return c.writeAtomicFunc(filePath, write, check)
+}
+
+// writeAtomicFunc is like writeAtomic but streams the content by calling write
+// with the temp file.
+func (c *config) writeAtomicFunc(filePath string, write func(w io.Writer) error, check func() error) error {
+	tmp, tmpName, err := c.createTemp(filePath)
+	if err != nil {
+		return err
+	}
+	if err := write(tmp); err != nil {
+		return errors.Join(fmt.Errorf("failed to write temp file: %w", err), tmp.Close(), c.remove(tmpName))
+	}
+	if err := tmp.Sync(); err != nil {
+		return errors.Join(fmt.Errorf("failed to sync temp file: %w", err), tmp.Close(), c.remove(tmpName))
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Join(fmt.Errorf("failed to close temp file: %w", err), c.remove(tmpName))
+	}
+	if check != nil {
+		if err := check(); err != nil {
+			return errors.Join(err, c.remove(tmpName))
+		}
+	}
+	if err := c.rename(tmpName, filePath); err != nil {
+		return errors.Join(fmt.Errorf("failed to rename temp file: %w", err), c.remove(tmpName))
+	}
+	return c.syncDir(filepath.Dir(filePath))
+}
+
+// syncDir fsyncs a directory so a rename into it is durable. Windows doesn't
+// support syncing directories, renames there are made durable by the file system.
+func (c *config) syncDir(dir string) error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+	d, err := c.open(dir)
+	if err != nil {
+		return fmt.Errorf("failed to open directory %q: %w", dir, err)
+	}
+	if err := d.Sync(); err != nil {
+		return errors.Join(fmt.Errorf("failed to sync directory %q: %w", dir, err), d.Close())
+	}
+	return d.Close()
+}
+
+// atomicFile is the writer of NewAtomicWriter. It writes to a sibling temp file
+// and only replaces the target on Close.
+type atomicFile struct {
+	*os.File
+	cfg      *config
+	filePath string
+	tmpName  string
+	// failed is set by a failed write, the temp file is then discarded on Close.
+	failed bool
+	// discarded is set by Abort, later writes are dropped and Close succeeds.
+	discarded bool
+	aborted   bool
+}
+
+func newAtomicFile(filePath string, cfg *config) (*atomicFile, error) {
+	tmp, tmpName, err := cfg.createTemp(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.chmod(tmp); err != nil {
+		return nil, errors.Join(err, tmp.Close(), cfg.remove(tmpName))
+	}
+	return &atomicFile{File: tmp, cfg: cfg, filePath: filePath, tmpName: tmpName}, nil
+}
+
+func (a *atomicFile) Write(p []byte) (int, error) {
+	if a.discarded {
+		return len(p), nil
+	}
+	n, err := a.File.Write(p)
+	if err != nil {
+		a.failed = true
+		return n, errors.Join(err, a.abort())
+	}
+	return n, nil
+}
+
+// WriteString is like Write, it shadows the promoted method of *os.File that
+// would bypass the failure tracking.
+func (a *atomicFile) WriteString(s string) (int, error) {
+	return a.Write([]byte(s))
+}
+
+// ReadFrom copies r into the temp file. A failed copy discards the temp file
+// like a failed Write, so the partial content never replaces the target.
+func (a *atomicFile) ReadFrom(r io.Reader) (int64, error) {
+	if a.discarded {
+		return io.Copy(io.Discard, r)
+	}
+	n, err := a.File.ReadFrom(r)
+	if err != nil {
+		a.failed = true
+		return n, errors.Join(err, a.abort())
+	}
+	return n, nil
+}
+
+// abort closes and removes the temp file, leaving the target untouched.
+func (a *atomicFile) abort() error {
+	if a.aborted {
+		return nil
+	}
+	a.aborted = true
+	return errors.Join(a.File.Close(), a.cfg.remove(a.tmpName))
+}
+
+// Close commits the temp file by renaming it over the target. If a write failed
+// before, the temp file is already removed and the target stays untouched.
+func (a *atomicFile) Close() error {
+	tmpName := a.tmpName
+	if a.discarded {
+		return nil
+	}
+	if a.failed {
+		return errors.Join(errors.New("discarded atomic write after a failed write"), a.abort())
+	}
+	fsync := !a.cfg.noFsync
+	if fsync {
+		if err := a.Sync(); err != nil {
+			return errors.Join(fmt.Errorf("failed to sync temp file: %w", err), a.File.Close(), a.cfg.remove(tmpName))
+		}
+	}
+	if err := a.File.Close(); err != nil {
+		return errors.Join(fmt.Errorf("failed to close temp file: %w", err), a.cfg.remove(tmpName))
+	}
+	if err := a.cfg.rename(tmpName, a.filePath); err != nil {
+		return errors.Join(fmt.Errorf("failed to rename temp file: %w", err), a.cfg.remove(tmpName))
+	}
+	if fsync {
+		return a.cfg.syncDir(filepath.Dir(a.filePath))
+	}
+	return nil
+}
+
+// NewAtomicWriter returns a File whose writes go to a temp file next to filePath
+// that replaces filePath only on Close. Readers see either the old or the complete
+// new content, never a partially written file.
+//
+// By default the temp file is fsynced before the rename and the directory after
+// it, so once Close returned the new content survives a crash or power loss.
+// WithoutFsync trades this durability for speed.
+func NewAtomicWriter(filePath string, opts ...Option) *File {
+	cfg := newConfig(opts)
+	cfg.atomic = true
+	return cfg.newFile(filePath)
+}
+
+// Abort discards everything written by an atomic writer and closes the file, so
+// the target stays untouched, e.g. when the source of a copy failed. Other
+// writers can't take back what they already wrote, for them Abort is the same
+// as Close.
+func (f *File) Abort() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var err error
+	if a := f.config().atomicTemp; a != nil && f.Writer != nil {
+		a.discarded = true
+		err = a.abort()
+	}
+	return errors.Join(err, f.close())
+}
@@ -0,0 +1,161 @@
+package file_test
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// @markdown
+// TestNewAtomicWriter illustrates how to replace a file so readers never see a partially written file.
+func TestNewAtomicWriter(t *testing.T) {
+	t.Parallel()
+	filePath := createFile(t, "old")
+
+	f := file.NewAtomicWriter(filePath)
+	_, err := f.Write([]byte("new"))
+	require.NoError(t, err)
+
+	// Until Close the old content is still in place
+	cnt, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, "old", string(cnt))
+
+	require.NoError(t, f.Close())
+
+	cnt, err = os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(cnt))
+
+	// Only the target is left in the directory
+	entries, err := os.ReadDir(filepath.Dir(filePath))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestNewAtomicWriterNewFile(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "output.log")
+
+	f := file.NewAtomicWriter(filePath)
+	_, err := f.Write([]byte("complete"))
+	require.NoError(t, err)
+
+	// The target does not exist until Close
+	_, err = os.Stat(filePath)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	require.NoError(t, f.Close())
+	cnt, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, "complete", string(cnt))
+}
+
+func TestNewAtomicWriterInterrupted(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "output.log")
+
+	// A writer that is never closed leaves no partial target behind
+	f := file.NewAtomicWriter(filePath)
+	_, err := f.Write([]byte("partial"))
+	require.NoError(t, err)
+
+	_, err = os.Stat(filePath)
+	require.ErrorIs(t, err, os.ErrNotExist)
+	matches, err := filepath.Glob(filepath.Join(dir, ".output.log.tmp-*"))
+	require.NoError(t, err)
+	assert.Len(t, matches, 1)
+}
+
+func TestNewAtomicWriterError(t *testing.T) {
+	t.Parallel()
+	filePath := createFile(t, "old")
+
+	// A writer that fails before Close removes its temp file
+	f := file.NewAtomicWriter(filePath, file.WithSidecarChecksum("crc"))
+	_, err := f.Write([]byte("new"))
+	require.ErrorContains(t, err, "unsupported checksum algorithm")
+
+	cnt, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, "old", string(cnt))
+	entries, err := os.ReadDir(filepath.Dir(filePath))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestNewAtomicWriterReadFromError(t *testing.T) {
+	t.Parallel()
+	filePath := createFile(t, "old")
+
+	// A source failing mid-copy discards the partially copied content
+	f := file.NewAtomicWriter(filePath)
+	src := io.MultiReader(strings.NewReader("partial"), iotest.ErrReader(errors.New("connection reset")))
+	_, err := io.Copy(f, src)
+	require.ErrorContains(t, err, "connection reset")
+	require.Error(t, f.Close())
+
+	cnt, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, "old", string(cnt))
+	entries, err := os.ReadDir(filepath.Dir(filePath))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestNewAtomicWriterAbort(t *testing.T) {
+	t.Parallel()
+	filePath := createFile(t, "old")
+
+	// Abort discards the written content when the source of a copy failed
+	f := file.NewAtomicWriter(filePath, file.WithSidecarChecksum("sha256"))
+	_, err := f.Write([]byte("new"))
+	require.NoError(t, err)
+	require.NoError(t, f.Abort())
+
+	cnt, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, "old", string(cnt))
+	entries, err := os.ReadDir(filepath.Dir(filePath))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestNewAtomicWriterWithoutFsync(t *testing.T) {
+	t.Parallel()
+	filePath := filepath.Join(t.TempDir(), "config", "app.json")
+
+	f := file.NewAtomicWriter(filePath, file.WithoutFsync())
+	_, err := f.Write([]byte("{}"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	cnt, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, "{}", string(cnt))
+}
+
+func TestNewAtomicWriterWithSidecarChecksum(t *testing.T) {
+	t.Parallel()
+	filePath := filepath.Join(t.TempDir(), "release.txt")
+
+	f := file.NewAtomicWriter(filePath, file.WithSidecarChecksum("sha256"))
+	_, err := f.Write([]byte("Hello, World!"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	cnt, err := os.ReadFile(filePath + ".sha256")
+	require.NoError(t, err)
+	assert.Equal(t, "dffd6021bb2bd5b0af676290809ec3a53191dd81c7f70a4b28688a362182986f  release.txt\n", string(cnt))
+}
@@ -0,0 +1,26 @@
+package file
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+)
+
+// NewBackupWriter returns a File like NewWriter that keeps the previous content
+// of filePath. Before the file is created on first write, an existing file is
+// renamed to filePath with the suffix ".bak", replacing an older backup. Use
+// WithBackupSuffix to change the suffix.
+func NewBackupWriter(filePath string, opts ...Option) *File {
+	cfg := newConfig(append([]Option{WithBackupSuffix(".bak")}, opts...))
+	cfg.backup = true
+	return cfg.newFile(filePath)
+}
+
+// backupFile renames an existing filePath to its backup name.
+func (c *config) backupFile(filePath string) error {
+	err := c.rename(filePath, filePath+c.backupSuffix)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to back up %q: %w", filePath, err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package file_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// @markdown
+// TestNewBackupWriter illustrates how to keep the previous version of a file when overwriting it.
+func TestNewBackupWriter(t *testing.T) {
+	t.Parallel()
+	filePath := createFile(t, "old")
+
+	f := file.NewBackupWriter(filePath)
+	_, err := f.WriteString("new")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	cnt, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(cnt))
+	cnt, err = os.ReadFile(filePath + ".bak")
+	require.NoError(t, err)
+	assert.Equal(t, "old", string(cnt))
+}
+
+func TestNewBackupWriterNewFile(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "config.json")
+
+	f := file.NewBackupWriter(filePath)
+	_, err := f.WriteString("{}")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	// Without a previous file no backup is created
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "config.json", entries[0].Name())
+}
+
+func TestNewBackupWriterWithBackupSuffix(t *testing.T) {
+	t.Parallel()
+	filePath := createFile(t, "old")
+
+	f := file.NewBackupWriter(filePath, file.WithBackupSuffix("~"))
+	_, err := f.WriteString("new")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	cnt, err := os.ReadFile(filePath + "~")
+	require.NoError(t, err)
+	assert.Equal(t, "old", string(cnt))
+}
@@ -0,0 +1,42 @@
+package file
+
+import (
+	"bytes"
+	"errors"
+	"io"
+)
+
+// utf8BOM is the byte order mark some tools put in front of UTF-8 text.
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// bomReader drops a leading UTF-8 BOM. The first Read looks at up to three bytes
+// and hands them on unless they are the BOM.
+type bomReader struct {
+	src     io.Reader
+	checked bool
+	head    []byte
+}
+
+func (b *bomReader) Read(p []byte) (int, error) {
+	if !b.checked {
+		b.checked = true
+		head := make([]byte, len(utf8BOM))
+		n, err := io.ReadFull(b.src, head)
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return 0, err
+		}
+		if !bytes.Equal(head[:n], utf8BOM) {
+			b.head = head[:n]
+		}
+	}
+	if len(b.head) > 0 {
+		n := copy(p, b.head)
+		b.head = b.head[n:]
+		return n, nil
+	}
+	return b.src.Read(p)
+}
+
+func (b *bomReader) Close() error {
+	return closeIfCloser(b.src)
+}
@@ -0,0 +1,37 @@
+package file_test
+
+import (
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithStripBOM(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{name: "bom", content: "\xEF\xBB\xBFkey,value\n", want: "key,value\n"},
+		{name: "no bom", content: "key,value\n", want: "key,value\n"},
+		{name: "only bom", content: "\xEF\xBB\xBF", want: ""},
+		{name: "one byte", content: "a", want: "a"},
+		{name: "partial bom", content: "\xEF\xBB", want: "\xEF\xBB"},
+		{name: "empty", content: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			f := file.New(createFile(t, tt.content), file.WithStripBOM())
+
+			cnt, err := f.Read()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(cnt))
+			require.NoError(t, f.Close())
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package file
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// WriteCAS atomically replaces the file content only if the modification time of
+// the file still equals expectedModTime, otherwise ErrConflict is returned. A zero
+// expectedModTime expects the file to not exist yet.
+func (f *File) WriteCAS(content []byte, expectedModTime time.Time) error {
+	if f.FilePath == "" {
+		return ErrNoPath
+	}
+	cfg := f.config()
+	check := func() error {
+		info, err := cfg.stat(f.FilePath)
+		switch {
+		case os.IsNotExist(err) && expectedModTime.IsZero():
+			return nil
+		case os.IsNotExist(err):
+			return fmt.Errorf("file %q was removed: %w", f.FilePath, ErrConflict)
+		case err != nil:
+			return err
+		case !info.ModTime().Equal(expectedModTime):
+			return fmt.Errorf("file %q was modified at %s: %w", f.FilePath, info.ModTime(), ErrConflict)
+		}
+		return nil
+	}
+	return cfg.writeAtomic(f.FilePath, content, check)
+}
@@ -0,0 +1,81 @@
+package file_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// @markdown
+// TestWriteCAS illustrates how to replace a file only if nobody else changed it since it was read.
+func TestWriteCAS(t *testing.T) {
+	t.Parallel()
+	tmpFile := createFile(t, "version 1")
+	info, err := os.Stat(tmpFile)
+	require.NoError(t, err)
+
+	f := file.New(tmpFile)
+	err = f.WriteCAS([]byte("version 2"), info.ModTime())
+	require.NoError(t, err)
+
+	cnt, err := os.ReadFile(tmpFile)
+	require.NoError(t, err)
+	assert.Equal(t, "version 2", string(cnt))
+}
+
+func TestWriteCASConflict(t *testing.T) {
+	t.Parallel()
+	tmpFile := createFile(t, "version 1")
+	info, err := os.Stat(tmpFile)
+	require.NoError(t, err)
+
+	// Someone else modified the file in the meantime
+	modified := info.ModTime().Add(time.Second)
+	require.NoError(t, os.Chtimes(tmpFile, modified, modified))
+
+	f := file.New(tmpFile)
+	err = f.WriteCAS([]byte("version 2"), info.ModTime())
+	require.ErrorIs(t, err, file.ErrConflict)
+
+	cnt, err := os.ReadFile(tmpFile)
+	require.NoError(t, err)
+	assert.Equal(t, "version 1", string(cnt))
+
+	// No temp file is left behind
+	entries, err := os.ReadDir(filepath.Dir(tmpFile))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestWriteCASCreate(t *testing.T) {
+	t.Parallel()
+	filePath := filepath.Join(t.TempDir(), "config.json")
+
+	f := file.New(filePath)
+	err := f.WriteCAS([]byte("{}"), time.Time{})
+	require.NoError(t, err)
+
+	// The file exists now so a second create conflicts
+	err = f.WriteCAS([]byte("{}"), time.Time{})
+	require.ErrorIs(t, err, file.ErrConflict)
+
+	// A removed file conflicts with an expected modification time
+	require.NoError(t, os.Remove(filePath))
+	err = f.WriteCAS([]byte("{}"), time.Now())
+	require.ErrorIs(t, err, file.ErrConflict)
+}
+
+func TestWriteCASNoPath(t *testing.T) {
+	t.Parallel()
+	f := file.NewReader(strings.NewReader("Hello, World!"))
+
+	err := f.WriteCAS([]byte("Hello"), time.Time{})
+	require.ErrorIs(t, err, file.ErrNoPath)
+}
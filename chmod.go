@@ -0,0 +1,30 @@
+package file
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Chmod changes the mode of the file, e.g. to 0o755 to make a written script
+// executable. An open writer that supports it, like the file created by
+// NewWriter, is changed directly, otherwise the file at the path is. Files backed
+// by an in-memory writer or a FileSystem of WithFS fail with
+// errors.ErrUnsupported.
+func (f *File) Chmod(mode os.FileMode) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Writer != nil {
+		if c, ok := f.Writer.Writer.(interface{ Chmod(mode os.FileMode) error }); ok {
+			return c.Chmod(mode)
+		}
+	}
+	filePath := f.path()
+	if filePath == "" {
+		if f.Writer != nil {
+			return fmt.Errorf("failed to chmod writer of type %T: %w", f.Writer.Writer, errors.ErrUnsupported)
+		}
+		return ErrNoPath
+	}
+	return f.config().chmodPath(filePath, mode)
+}
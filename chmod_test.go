@@ -0,0 +1,55 @@
+package file_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChmod(t *testing.T) {
+	t.Parallel()
+	if runtime.GOOS == "windows" {
+		t.Skip("Windows has no executable bits")
+	}
+	testFilePath := filepath.Join(t.TempDir(), "run.sh")
+
+	f := file.NewWriter(testFilePath)
+	_, err := f.WriteString("#!/bin/sh\necho hello\n")
+	require.NoError(t, err)
+	// The open writer is changed directly
+	require.NoError(t, f.Chmod(0o755))
+	require.NoError(t, f.Close())
+
+	info, err := os.Stat(testFilePath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o755), info.Mode())
+
+	// Without an open writer the file at the path is changed
+	require.NoError(t, file.New(testFilePath).Chmod(0o700))
+	info, err = os.Stat(testFilePath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o700), info.Mode())
+}
+
+func TestChmodError(t *testing.T) {
+	t.Parallel()
+	err := file.NewReader(bytes.NewBufferString("")).Chmod(0o755)
+	require.ErrorIs(t, err, file.ErrNoPath)
+
+	err = file.NewWriterBuffer(&bytes.Buffer{}, "run.sh").Chmod(0o755)
+	require.ErrorIs(t, err, file.ErrNoPath)
+
+	err = file.New(filepath.Join(t.TempDir(), "missing.sh")).Chmod(0o755)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	err = file.New("run.sh", file.WithFS(file.NewMemFS())).Chmod(0o755)
+	require.ErrorIs(t, err, errors.ErrUnsupported)
+}
@@ -0,0 +1,15 @@
+package file
+
+import "time"
+
+// Chtimes changes the access and modification times of the file at the path,
+// e.g. to get reproducible build artifacts. Call it after Close, as writing
+// updates the modification time again. Files without a path fail with
+// ErrNoPath.
+func (f *File) Chtimes(atime, mtime time.Time) error {
+	filePath := f.path()
+	if filePath == "" {
+		return ErrNoPath
+	}
+	return f.config().chtimes(filePath, atime, mtime)
+}
@@ -0,0 +1,34 @@
+package file_test
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChtimes(t *testing.T) {
+	t.Parallel()
+	testFilePath := createFile(t, "artifact")
+	mtime := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	require.NoError(t, file.New(testFilePath).Chtimes(mtime, mtime))
+
+	info, err := os.Stat(testFilePath)
+	require.NoError(t, err)
+	assert.True(t, mtime.Equal(info.ModTime()), info.ModTime())
+}
+
+func TestChtimesError(t *testing.T) {
+	t.Parallel()
+	err := file.NewReader(bytes.NewBufferString("")).Chtimes(time.Now(), time.Now())
+	require.ErrorIs(t, err, file.ErrNoPath)
+
+	err = file.New("nonexistent.txt").Chtimes(time.Now(), time.Now())
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
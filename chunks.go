@@ -0,0 +1,39 @@
+package file
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+)
+
+// Chunks returns an iterator over the content of the file in blocks of size
+// bytes, e.g. for a chunked upload. Only the last chunk may be shorter. The
+// reader is opened lazily and open errors are returned right away, read errors
+// are yielded at the end of the sequence. A yielded chunk is only valid until the
+// next iteration, copy it to keep it.
+func (f *File) Chunks(size int) (iter.Seq2[[]byte, error], error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("invalid chunk size %d", size)
+	}
+	reader, err := f.lazyReader()
+	if err != nil {
+		return nil, err
+	}
+	return func(yield func([]byte, error) bool) {
+		buf := make([]byte, size)
+		for {
+			n, err := io.ReadFull(reader, buf)
+			if n > 0 && !yield(buf[:n], nil) {
+				return
+			}
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return
+			}
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+		}
+	}, nil
+}
@@ -0,0 +1,62 @@
+package file_test
+
+import (
+	"bytes"
+	"testing"
+	"testing/iotest"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChunks(t *testing.T) {
+	t.Parallel()
+	f := file.New(createFile(t, "Hello, World!"))
+
+	chunks, err := f.Chunks(5)
+	require.NoError(t, err)
+	var got []string
+	for chunk, err := range chunks {
+		require.NoError(t, err)
+		got = append(got, string(chunk))
+	}
+	// The last chunk holds the remainder
+	assert.Equal(t, []string{"Hello", ", Wor", "ld!"}, got)
+	require.NoError(t, f.Close())
+}
+
+func TestChunksStop(t *testing.T) {
+	t.Parallel()
+	chunks, err := file.NewReader(bytes.NewBufferString("Hello, World!")).Chunks(5)
+	require.NoError(t, err)
+	for chunk := range chunks {
+		assert.Equal(t, "Hello", string(chunk))
+		break
+	}
+}
+
+func TestChunksError(t *testing.T) {
+	t.Parallel()
+	_, err := file.New("nonexistent.txt").Chunks(5)
+	require.Error(t, err)
+
+	_, err = file.New(createFile(t, "")).Chunks(0)
+	require.ErrorContains(t, err, "invalid chunk size")
+
+	chunks, err := file.NewReader(iotest.TimeoutReader(bytes.NewBufferString("Hello, World!"))).Chunks(20)
+	require.NoError(t, err)
+	// The bytes read before the error are still yielded
+	var got []string
+	var readErr error
+	for chunk, err := range chunks {
+		if err != nil {
+			readErr = err
+			continue
+		}
+		got = append(got, string(chunk))
+	}
+	assert.Equal(t, []string{"Hello, World!"}, got)
+	require.ErrorIs(t, readErr, iotest.ErrTimeout)
+}
@@ -0,0 +1,56 @@
+package file
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Decode passes the lazily opened reader to fn, e.g. a gob or protobuf decoder,
+// and closes the reader afterwards.
+func (f *File) Decode(fn func(io.Reader) error) error {
+	reader, err := f.lazyReader()
+	if err != nil {
+		return err
+	}
+	if err = fn(reader); err != nil {
+		err = fmt.Errorf("failed to decode file %q: %w", f.FilePath, err)
+	}
+	return errors.Join(err, f.closeReader())
+}
+
+// Encode passes the lazily created writer to fn, e.g. a gob or protobuf encoder,
+// and closes the writer afterwards. Other writes wait until fn returns.
+func (f *File) Encode(fn func(io.Writer) error) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	writer, err := f.lazyWriter()
+	if err != nil {
+		return err
+	}
+	if err = fn(writer); err != nil {
+		err = fmt.Errorf("failed to encode file %q: %w", writer.FilePath, err)
+	}
+	return errors.Join(err, f.closeWriter())
+}
+
+// closeReader closes and forgets the opened reader.
+func (f *File) closeReader() (err error) {
+	if closer, ok := f.Reader.(io.Closer); ok {
+		err = closer.Close()
+	}
+	f.Reader = nil
+	return err
+}
+
+// closeWriter closes and forgets the created writer.
+func (f *File) closeWriter() (err error) {
+	if f.Writer == nil {
+		return nil
+	}
+	if closer, ok := f.Writer.Writer.(io.Closer); ok {
+		err = closer.Close()
+	}
+	f.Writer = nil
+	return err
+}
@@ -0,0 +1,70 @@
+package file_test
+
+import (
+	"encoding/gob"
+	"errors"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type record struct {
+	Name  string
+	Count int
+}
+
+// @markdown
+// TestEncodeDecode illustrates how to plug any encoder or decoder, like encoding/gob, into a File.
+func TestEncodeDecode(t *testing.T) {
+	t.Parallel()
+	filePath := filepath.Join(t.TempDir(), "record.gob")
+
+	err := file.New(filePath).Encode(func(w io.Writer) error {
+		return gob.NewEncoder(w).Encode(record{Name: "gopher", Count: 42})
+	})
+	require.NoError(t, err)
+
+	f := file.New(filePath)
+	var got record
+	err = f.Decode(func(r io.Reader) error {
+		return gob.NewDecoder(r).Decode(&got)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, record{Name: "gopher", Count: 42}, got)
+
+	// The reader was closed by Decode already
+	assert.Nil(t, f.Reader)
+	require.NoError(t, f.Close())
+}
+
+func TestDecodeErrors(t *testing.T) {
+	t.Parallel()
+	err := file.NewReaderError(io.ErrUnexpectedEOF).Decode(func(io.Reader) error {
+		return nil
+	})
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+	err = file.New(createFile(t, "not gob")).Decode(func(r io.Reader) error {
+		return gob.NewDecoder(r).Decode(&record{})
+	})
+	assert.ErrorContains(t, err, "failed to decode file")
+}
+
+func TestEncodeErrors(t *testing.T) {
+	t.Parallel()
+	err := file.NewWriterError(io.ErrClosedPipe).Encode(func(io.Writer) error {
+		return nil
+	})
+	require.ErrorIs(t, err, io.ErrClosedPipe)
+
+	err = file.New(filepath.Join(t.TempDir(), "record.gob")).Encode(func(io.Writer) error {
+		return errors.ErrUnsupported
+	})
+	require.ErrorIs(t, err, errors.ErrUnsupported)
+	assert.ErrorContains(t, err, "failed to encode file")
+}
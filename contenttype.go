@@ -0,0 +1,73 @@
+package file
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+// sniffLen is the number of bytes http.DetectContentType considers.
+const sniffLen = 512
+
+// DetectContentType returns the MIME type of the file, e.g. "image/png", by
+// sniffing its first 512 bytes with http.DetectContentType. For Files with a path
+// the extension decides if sniffing only finds generic text or binary data, e.g.
+// "application/json" for a .json file. The content stays available for the
+// following reads: Files with a path are sniffed with their own file handle and
+// the sniffed bytes of in-memory readers are put back in front of the reader.
+func (f *File) DetectContentType() (string, error) {
+	if filePath := f.path(); filePath != "" {
+		head, err := f.sniffPath(filePath)
+		if err != nil {
+			return "", err
+		}
+		contentType := http.DetectContentType(head)
+		if ext := mime.TypeByExtension(filepath.Ext(filePath)); ext != "" && isGenericContentType(contentType) {
+			return ext, nil
+		}
+		return contentType, nil
+	}
+	reader, err := f.lazyReader()
+	if err != nil {
+		return "", err
+	}
+	head, err := readHead(reader)
+	if err != nil {
+		return "", err
+	}
+	f.Reader = readCloser{Reader: io.MultiReader(bytes.NewReader(head), reader), Closer: closeFunc(func() error {
+		return closeIfCloser(reader)
+	})}
+	return http.DetectContentType(head), nil
+}
+
+// sniffPath reads the first bytes of filePath with a reader of its own.
+func (f *File) sniffPath(filePath string) ([]byte, error) {
+	reader, err := readerFunc(filePath, f.config())()
+	if err != nil {
+		return nil, err
+	}
+	head, err := readHead(reader)
+	return head, errors.Join(err, closeIfCloser(reader))
+}
+
+// readHead reads up to sniffLen bytes, less only if the reader ends before.
+func readHead(r io.Reader) ([]byte, error) {
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(r, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, fmt.Errorf("failed to read content to detect its type: %w", err)
+	}
+	return head[:n], nil
+}
+
+// isGenericContentType reports whether sniffing found no more specific type than
+// plain text or arbitrary binary data.
+func isGenericContentType(contentType string) bool {
+	return contentType == "application/octet-stream" || strings.HasPrefix(contentType, "text/plain")
+}
@@ -0,0 +1,79 @@
+package file_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pngHeader is the signature every PNG image starts with.
+const pngHeader = "\x89PNG\r\n\x1a\n"
+
+func TestDetectContentType(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{name: "png", content: pngHeader + "\x00\x00\x00\rIHDR", want: "image/png"},
+		{name: "text", content: "Hello, World!", want: "text/plain; charset=utf-8"},
+		{name: "empty", content: "", want: "text/plain; charset=utf-8"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			f := file.New(createFile(t, tt.content))
+
+			contentType, err := f.DetectContentType()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, contentType)
+
+			// The whole content is still read afterwards
+			cnt, err := f.Read()
+			require.NoError(t, err)
+			assert.Equal(t, tt.content, string(cnt))
+			require.NoError(t, f.Close())
+		})
+	}
+}
+
+func TestDetectContentTypeExtension(t *testing.T) {
+	t.Parallel()
+	filePath := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(filePath, []byte(`{"debug":true}`), 0o600))
+
+	contentType, err := file.New(filePath).DetectContentType()
+	require.NoError(t, err)
+	assert.Equal(t, "application/json", contentType)
+}
+
+func TestDetectContentTypeReader(t *testing.T) {
+	t.Parallel()
+	content := pngHeader + string(bytes.Repeat([]byte{0}, 1024))
+	f := file.NewReader(bytes.NewBufferString(content))
+
+	contentType, err := f.DetectContentType()
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", contentType)
+
+	// The sniffed bytes are not lost for the reader that can't seek
+	cnt, err := f.Read()
+	require.NoError(t, err)
+	assert.Equal(t, content, string(cnt))
+}
+
+func TestDetectContentTypeError(t *testing.T) {
+	t.Parallel()
+	_, err := file.New("nonexistent.png").DetectContentType()
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	_, err = file.NewReaderError(os.ErrPermission).DetectContentType()
+	require.ErrorIs(t, err, os.ErrPermission)
+}
@@ -0,0 +1,35 @@
+package file
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ReadContext reads the whole file like Read but gives up when ctx is done and
+// returns ctx.Err(). In that case the reader is closed if it implements
+// io.Closer, which unblocks the pending read of e.g. a network stream.
+func (f *File) ReadContext(ctx context.Context) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	reader, err := f.lazyReader()
+	if err != nil {
+		return nil, err
+	}
+	type result struct {
+		cnt []byte
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		cnt, err := io.ReadAll(reader)
+		done <- result{cnt: cnt, err: err}
+	}()
+	select {
+	case res := <-done:
+		return res.cnt, res.err
+	case <-ctx.Done():
+		return nil, errors.Join(ctx.Err(), f.closeReader())
+	}
+}
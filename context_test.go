@@ -0,0 +1,48 @@
+package file_test
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadContext(t *testing.T) {
+	t.Parallel()
+	f := file.New(createFile(t, "Hello, World!"))
+
+	cnt, err := f.ReadContext(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, World!", string(cnt))
+	require.NoError(t, f.Close())
+}
+
+func TestReadContextCanceled(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	_, err := file.New(createFile(t, "Hello, World!")).ReadContext(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestReadContextTimeout(t *testing.T) {
+	t.Parallel()
+	pr, pw := io.Pipe()
+	ctx, cancel := context.WithTimeout(t.Context(), 20*time.Millisecond)
+	defer cancel()
+
+	// The pipe blocks until something is written
+	f := file.NewReader(pr)
+	_, err := f.ReadContext(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// The reader was closed to unblock the pending read
+	_, err = pw.Write([]byte("late"))
+	require.ErrorIs(t, err, io.ErrClosedPipe)
+}
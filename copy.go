@@ -0,0 +1,86 @@
+package file
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// progressInterval is the number of bytes after which CopyWithProgress reports.
+const progressInterval = 32 * 1024
+
+// Copy copies the file at src to dst and returns the number of bytes copied.
+// Like NewWriter it creates the parent directory of dst and replaces an existing
+// dst.
+func Copy(src, dst string) (int64, error) {
+	return CopyWithProgress(src, dst, nil)
+}
+
+// CopyWithProgress copies the file at src to dst like Copy and calls fn with the
+// number of bytes copied so far about every 32 KiB and once the copy is
+// complete. A nil fn is ignored.
+func CopyWithProgress(src, dst string, fn func(bytesCopied int64)) (n int64, err error) {
+	in := New(src)
+	defer func() {
+		err = errors.Join(err, in.Close())
+	}()
+	reader, err := in.lazyReader()
+	if err != nil {
+		return 0, err
+	}
+	// Creating dst would truncate src before it is read.
+	if sameFile(src, dst) {
+		return 0, fmt.Errorf("failed to copy %q to %q: they are the same file", src, dst)
+	}
+	out := NewWriter(dst)
+	defer func() {
+		err = errors.Join(err, out.Close())
+	}()
+	if fn == nil {
+		return out.ReadFrom(reader)
+	}
+	w, err := out.lazyWriter()
+	if err != nil {
+		return 0, err
+	}
+	pw := &progressWriter{Writer: w, fn: fn}
+	n, err = io.Copy(pw, reader)
+	if err == nil && pw.reported != n {
+		fn(n)
+	}
+	return n, err
+}
+
+// sameFile reports whether both paths exist and refer to the same file, e.g.
+// through a link or a differently spelled path.
+func sameFile(a, b string) bool {
+	infoA, err := os.Stat(a)
+	if err != nil {
+		return false
+	}
+	infoB, err := os.Stat(b)
+	if err != nil {
+		return false
+	}
+	return os.SameFile(infoA, infoB)
+}
+
+// progressWriter reports the number of written bytes to fn about every
+// progressInterval bytes.
+type progressWriter struct {
+	io.Writer
+	fn       func(int64)
+	written  int64
+	reported int64
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.Writer.Write(b)
+	p.written += int64(n)
+	if p.written-p.reported >= progressInterval {
+		p.reported = p.written
+		p.fn(p.written)
+	}
+	return n, err
+}
@@ -0,0 +1,93 @@
+package file_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// @markdown
+// TestCopy illustrates how to copy a file to a new location.
+func TestCopy(t *testing.T) {
+	t.Parallel()
+	src := createFile(t, "Hello, World!")
+	dst := filepath.Join(t.TempDir(), "backup", "copy.txt")
+
+	n, err := file.Copy(src, dst)
+	require.NoError(t, err)
+	assert.Equal(t, int64(13), n)
+
+	cnt, err := os.ReadFile(dst)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, World!", string(cnt))
+}
+
+func TestCopyMissingSource(t *testing.T) {
+	t.Parallel()
+	dst := filepath.Join(t.TempDir(), "copy.txt")
+
+	_, err := file.Copy("nonexistent.txt", dst)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	// The destination is not created for a missing source
+	_, err = os.Stat(dst)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestCopySameFile(t *testing.T) {
+	t.Parallel()
+	src := createFile(t, "Hello, World!")
+	dir, name := filepath.Split(src)
+
+	for _, dst := range []string{src, filepath.Join(dir, ".", name)} {
+		_, err := file.Copy(src, dst)
+		require.ErrorContains(t, err, "same file")
+
+		// The source is left intact
+		cnt, err := os.ReadFile(src)
+		require.NoError(t, err)
+		assert.Equal(t, "Hello, World!", string(cnt))
+	}
+}
+
+func TestCopyUnwritableDestination(t *testing.T) {
+	t.Parallel()
+	src := createFile(t, "Hello, World!")
+	// A regular file can't be used as parent directory
+	dst := filepath.Join(createFile(t, "not a directory"), "copy.txt")
+
+	_, err := file.Copy(src, dst)
+	require.ErrorContains(t, err, "failed to create directory")
+}
+
+// @markdown
+// TestCopyWithProgress illustrates how to report the progress of copying a large file.
+func TestCopyWithProgress(t *testing.T) {
+	t.Parallel()
+	src := createFile(t, strings.Repeat("x", 3*1024*1024+123))
+	dst := filepath.Join(t.TempDir(), "copy.bin")
+
+	var progress []int64
+	n, err := file.CopyWithProgress(src, dst, func(bytesCopied int64) {
+		progress = append(progress, bytesCopied)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3*1024*1024+123), n)
+	require.Greater(t, len(progress), 1)
+	assert.IsIncreasing(t, progress)
+	assert.Equal(t, n, progress[len(progress)-1])
+
+	info, err := os.Stat(dst)
+	require.NoError(t, err)
+	assert.Equal(t, n, info.Size())
+
+	n, err = file.CopyWithProgress(src, filepath.Join(t.TempDir(), "copy.bin"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3*1024*1024+123), n)
+}
@@ -0,0 +1,52 @@
+package file
+
+import (
+	"bytes"
+	"errors"
+	"io"
+)
+
+// CountLines returns the number of lines of the file. A last line without a
+// trailing newline is counted as well. The reader is streamed in blocks, so
+// unlike Lines there is no limit on the line length.
+func (f *File) CountLines() (int, error) {
+	reader, err := f.lazyReader()
+	if err != nil {
+		return 0, err
+	}
+	buf := make([]byte, 32*1024)
+	lines := 0
+	last := byte('\n')
+	for {
+		n, err := reader.Read(buf)
+		if n > 0 {
+			lines += bytes.Count(buf[:n], []byte{'\n'})
+			last = buf[n-1]
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	if last != '\n' {
+		lines++
+	}
+	return lines, nil
+}
+
+// CountBytes returns the number of bytes of the file. Files with a path report
+// the size from Stat without reading, others and Files whose reader
+// decompresses or otherwise transforms the content stream their reader to the
+// end.
+func (f *File) CountBytes() (int64, error) {
+	if filePath := f.path(); filePath != "" && f.config().readsRaw(filePath) {
+		return f.Size()
+	}
+	reader, err := f.lazyReader()
+	if err != nil {
+		return 0, err
+	}
+	return io.Copy(io.Discard, reader)
+}
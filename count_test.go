@@ -0,0 +1,60 @@
+package file_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountLines(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		content string
+		want    int
+	}{
+		{name: "trailing newline", content: "one\ntwo\nthree\n", want: 3},
+		{name: "no trailing newline", content: "one\ntwo\nthree", want: 3},
+		{name: "blank lines", content: "\n\n", want: 2},
+		{name: "empty", content: "", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			lines, err := file.New(createFile(t, tt.content)).CountLines()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, lines)
+
+			size, err := file.New(createFile(t, tt.content)).CountBytes()
+			require.NoError(t, err)
+			assert.Equal(t, int64(len(tt.content)), size)
+		})
+	}
+}
+
+func TestCountBytesReader(t *testing.T) {
+	t.Parallel()
+	size, err := file.NewReader(bytes.NewBufferString("Hello, World!")).CountBytes()
+	require.NoError(t, err)
+	assert.Equal(t, int64(13), size)
+}
+
+func TestCountBytesDecompressed(t *testing.T) {
+	t.Parallel()
+	size, err := file.NewCompressedReader(createGzipFile(t, "Hello, World!")).CountBytes()
+	require.NoError(t, err)
+	assert.Equal(t, int64(13), size)
+}
+
+func TestCountError(t *testing.T) {
+	t.Parallel()
+	_, err := file.New("nonexistent.txt").CountLines()
+	require.Error(t, err)
+
+	_, err = file.New("nonexistent.txt").CountBytes()
+	require.Error(t, err)
+}
@@ -0,0 +1,75 @@
+package file
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+)
+
+// ReadCSV returns all records of the CSV content of the file. Use
+// WithCSVDelimiter for other separators than a comma and WithCSVHeader to skip
+// the header row. Use CSVRows to stream huge files instead.
+func (f *File) ReadCSV(opts ...Option) ([][]string, error) {
+	var records [][]string
+	err := f.scanCSV(f.options(opts), func(record []string) error {
+		records = append(records, record)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// CSVRows returns an iterator over the records of the CSV content of the file
+// with the same options as ReadCSV. The reader is opened lazily and open errors
+// are returned right away, parse and read errors are yielded at the end of the
+// sequence.
+func (f *File) CSVRows(opts ...Option) (iter.Seq2[[]string, error], error) {
+	cfg := f.options(opts)
+	if _, err := f.lazyReader(); err != nil {
+		return nil, err
+	}
+	return func(yield func([]string, error) bool) {
+		stopped := false
+		err := f.scanCSV(cfg, func(record []string) error {
+			if !yield(record, nil) {
+				stopped = true
+				return errStopIteration
+			}
+			return nil
+		})
+		if err != nil && !stopped {
+			yield(nil, err)
+		}
+	}, nil
+}
+
+// scanCSV calls fn for every record of the lazily opened reader.
+func (f *File) scanCSV(cfg *config, fn func(record []string) error) error {
+	reader, err := f.lazyReader()
+	if err != nil {
+		return err
+	}
+	cr := csv.NewReader(reader)
+	if cfg.csvDelimiter != 0 {
+		cr.Comma = cfg.csvDelimiter
+	}
+	for i := 0; ; i++ {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read csv %q: %w", f.FilePath, err)
+		}
+		if i == 0 && cfg.csvHeader {
+			continue
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package file_test
+
+import (
+	"encoding/csv"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadCSV(t *testing.T) {
+	t.Parallel()
+	f := file.New(createFile(t, "name,age\nAlice,30\nBob,40\n"))
+
+	records, err := f.ReadCSV()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"name", "age"}, {"Alice", "30"}, {"Bob", "40"}}, records)
+	require.NoError(t, f.Close())
+}
+
+func TestReadCSVWithCSVDelimiter(t *testing.T) {
+	t.Parallel()
+	f := file.New(createFile(t, "name;age\nAlice;30\nBob;40\n"))
+
+	records, err := f.ReadCSV(file.WithCSVDelimiter(';'), file.WithCSVHeader())
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"Alice", "30"}, {"Bob", "40"}}, records)
+	require.NoError(t, f.Close())
+}
+
+// @markdown
+// TestCSVRows illustrates how to stream the records of a huge CSV file.
+func TestCSVRows(t *testing.T) {
+	t.Parallel()
+	f := file.New(createFile(t, "name,age\nAlice,30\nBob,40\n"))
+
+	rows, err := f.CSVRows(file.WithCSVHeader())
+	require.NoError(t, err)
+	var names []string
+	for record, err := range rows {
+		require.NoError(t, err)
+		names = append(names, record[0])
+	}
+	assert.Equal(t, []string{"Alice", "Bob"}, names)
+	require.NoError(t, f.Close())
+}
+
+func TestReadCSVError(t *testing.T) {
+	t.Parallel()
+	_, err := file.New(createFile(t, "name,age\nAlice\n")).ReadCSV()
+	require.ErrorIs(t, err, csv.ErrFieldCount)
+
+	rows, err := file.New(createFile(t, "name,age\nAlice\n")).CSVRows()
+	require.NoError(t, err)
+	var last error
+	for _, err := range rows {
+		last = err
+	}
+	require.ErrorIs(t, last, csv.ErrFieldCount)
+
+	_, err = file.New("nonexistent.csv").CSVRows()
+	require.Error(t, err)
+}
@@ -0,0 +1,54 @@
+package file
+
+import (
+	"errors"
+	"io"
+	"iter"
+	"os"
+	"path/filepath"
+)
+
+// readDirBatch is the number of directory entries ReadDirSeq reads at once.
+const readDirBatch = 256
+
+// ReadDir returns a File for every entry of dir sorted by name.
+func ReadDir(dir string) ([]*File, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]*File, 0, len(entries))
+	for _, entry := range entries {
+		files = append(files, New(filepath.Join(dir, entry.Name())))
+	}
+	return files, nil
+}
+
+// ReadDirSeq yields a File for every entry of dir in directory order. Unlike
+// ReadDir it reads the entries in batches, so memory stays bounded even for
+// huge directories. Errors are yielded with a nil File and end the sequence.
+func ReadDirSeq(dir string) iter.Seq2[*File, error] {
+	return func(yield func(*File, error) bool) {
+		fh, err := os.Open(dir)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		defer fh.Close()
+		for {
+			entries, err := fh.ReadDir(readDirBatch)
+			for _, entry := range entries {
+				if !yield(New(filepath.Join(dir, entry.Name())), nil) {
+					return
+				}
+			}
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package file_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createFiles(t *testing.T, n int) string {
+	t.Helper()
+	dir := t.TempDir()
+	for i := range n {
+		err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("file-%04d.txt", i)), []byte("Hello, World!"), 0o600)
+		require.NoError(t, err)
+	}
+	return dir
+}
+
+func TestReadDir(t *testing.T) {
+	t.Parallel()
+	dir := createFiles(t, 3)
+
+	files, err := file.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 3)
+	assert.Equal(t, filepath.Join(dir, "file-0000.txt"), files[0].FilePath)
+
+	cnt, err := files[2].Read()
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, World!", string(cnt))
+
+	_, err = file.ReadDir(filepath.Join(dir, "missing"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+// @markdown
+// TestReadDirSeq illustrates how to iterate over a huge directory without loading all entries at once.
+func TestReadDirSeq(t *testing.T) {
+	t.Parallel()
+	dir := createFiles(t, 600)
+
+	seen := map[string]bool{}
+	for f, err := range file.ReadDirSeq(dir) {
+		require.NoError(t, err)
+		seen[filepath.Base(f.FilePath)] = true
+	}
+	assert.Len(t, seen, 600)
+	assert.True(t, seen["file-0599.txt"])
+}
+
+func TestReadDirSeqEarlyTermination(t *testing.T) {
+	t.Parallel()
+	dir := createFiles(t, 10)
+
+	count := 0
+	for _, err := range file.ReadDirSeq(dir) {
+		require.NoError(t, err)
+		count++
+		if count == 3 {
+			break
+		}
+	}
+	assert.Equal(t, 3, count)
+}
+
+func TestReadDirSeqError(t *testing.T) {
+	t.Parallel()
+	for f, err := range file.ReadDirSeq(filepath.Join(t.TempDir(), "missing")) {
+		assert.Nil(t, f)
+		assert.True(t, os.IsNotExist(err))
+	}
+}
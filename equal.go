@@ -0,0 +1,64 @@
+package file
+
+import (
+	"bytes"
+	"errors"
+	"io"
+)
+
+// equalBlockSize is the number of bytes Equal compares at once.
+const equalBlockSize = 32 * 1024
+
+// Equal reports whether the files at a and b have identical content. Files of
+// different size are unequal without reading them, otherwise both are compared
+// block by block up to the first difference. A missing file is an error, also if
+// both are missing.
+func Equal(a, b string) (equal bool, err error) {
+	fa, fb := New(a), New(b)
+	sizeA, err := fa.Size()
+	if err != nil {
+		return false, err
+	}
+	sizeB, err := fb.Size()
+	if err != nil {
+		return false, err
+	}
+	if sizeA != sizeB {
+		return false, nil
+	}
+	defer func() {
+		err = errors.Join(err, fa.Close(), fb.Close())
+	}()
+	ra, err := fa.lazyReader()
+	if err != nil {
+		return false, err
+	}
+	rb, err := fb.lazyReader()
+	if err != nil {
+		return false, err
+	}
+	bufA, bufB := make([]byte, equalBlockSize), make([]byte, equalBlockSize)
+	for {
+		na, errA := io.ReadFull(ra, bufA)
+		nb, errB := io.ReadFull(rb, bufB)
+		if !bytes.Equal(bufA[:na], bufB[:nb]) {
+			return false, nil
+		}
+		doneA, doneB := isEOF(errA), isEOF(errB)
+		if errA != nil && !doneA {
+			return false, errA
+		}
+		if errB != nil && !doneB {
+			return false, errB
+		}
+		if doneA || doneB {
+			// A file that changed since Stat may end earlier than the other
+			return doneA == doneB, nil
+		}
+	}
+}
+
+// isEOF reports whether err of io.ReadFull means the reader ended.
+func isEOF(err error) bool {
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
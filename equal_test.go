@@ -0,0 +1,50 @@
+package file_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEqual(t *testing.T) {
+	t.Parallel()
+	content := strings.Repeat("Hello, World!\n", 10000)
+	changed := []byte(content)
+	changed[len(changed)-2] = '?'
+	tests := []struct {
+		name string
+		a, b string
+		want bool
+	}{
+		{name: "identical", a: content, b: content, want: true},
+		{name: "one byte differs", a: content, b: string(changed), want: false},
+		{name: "different length", a: content, b: content + "!", want: false},
+		{name: "empty", a: "", b: "", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			equal, err := file.Equal(createFile(t, tt.a), createFile(t, tt.b))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, equal)
+		})
+	}
+}
+
+func TestEqualError(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+
+	// Two missing files are not equal
+	_, err := file.Equal(filepath.Join(dir, "a.txt"), filepath.Join(dir, "b.txt"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	_, err = file.Equal(createFile(t, "Hello, World!"), filepath.Join(dir, "b.txt"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,53 @@
+package file
+
+import "errors"
+
+var (
+	// ErrNoPath is returned by operations that need a file system path on a File
+	// that is only backed by an in-memory reader or writer.
+	ErrNoPath = errors.New("file has no path")
+
+	// ErrInvalidRange is returned when a requested byte range does not fit the file.
+	ErrInvalidRange = errors.New("invalid byte range")
+
+	// ErrConflict is returned by WriteCAS when the file was modified concurrently.
+	ErrConflict = errors.New("file was modified concurrently")
+
+	// ErrShortRecord is returned when a fixed-width record is shorter than its fields.
+	ErrShortRecord = errors.New("record is shorter than the field widths")
+
+	// ErrLocked is returned when a lock is already held by someone else.
+	ErrLocked = errors.New("file is locked")
+
+	// ErrNotFound is returned when a searched pattern does not occur in the file.
+	ErrNotFound = errors.New("not found")
+
+	// ErrCorruptRecord is returned when a record of a record log fails its checksum.
+	ErrCorruptRecord = errors.New("corrupt record")
+
+	// ErrRecordTooLong is returned when a write does not fit the fixed record size.
+	ErrRecordTooLong = errors.New("record exceeds the record size")
+
+	// ErrNotSeekable is returned when a File is backed by a reader that cannot seek.
+	ErrNotSeekable = errors.New("reader is not seekable")
+
+	// ErrQuotaExceeded is returned when a write exceeds the size limit of a writer.
+	ErrQuotaExceeded = errors.New("write quota exceeded")
+
+	// ErrUnsafePath is returned when a path escapes the directory it must stay in.
+	ErrUnsafePath = errors.New("path escapes root")
+
+	// ErrNilWriter is returned when writing to a File whose writer func returned
+	// no Writer.
+	ErrNilWriter = errors.New("unexpected Writer is nil")
+
+	// ErrCreateDir is returned when the directory of a file to write can't be
+	// created.
+	ErrCreateDir = errors.New("failed to create directory")
+
+	// ErrCreateFile is returned when a file to write can't be created.
+	ErrCreateFile = errors.New("failed to create file")
+)
+
+// errStopIteration ends a scan early when the consumer of an iterator stops.
+var errStopIteration = errors.New("stop iteration")
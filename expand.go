@@ -0,0 +1,30 @@
+package file
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ExpandPath expands a leading "~" in p to the home directory of the current
+// user and replaces $VAR and ${VAR} with the values of the environment
+// variables, see os.ExpandEnv.
+func ExpandPath(p string) (string, error) {
+	if p == "~" || strings.HasPrefix(p, "~/") || strings.HasPrefix(p, "~"+string(filepath.Separator)) {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to expand %q: %w", p, err)
+		}
+		p = home + p[1:]
+	}
+	return os.ExpandEnv(p), nil
+}
+
+// expandPath applies ExpandPath to filePath if WithExpandPath is set.
+func (c *config) expandPath(filePath string) (string, error) {
+	if !c.expand {
+		return filePath, nil
+	}
+	return ExpandPath(filePath)
+}
@@ -0,0 +1,69 @@
+package file_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandPath(t *testing.T) {
+	t.Parallel()
+	home, err := os.UserHomeDir()
+	require.NoError(t, err)
+
+	for path, expected := range map[string]string{
+		"~":                                  home,
+		"~/.config/app.json":                 home + "/.config/app.json",
+		"$HOME/.config/app.json":             os.Getenv("HOME") + "/.config/app.json",
+		"/etc/app/config.json":               "/etc/app/config.json",
+		"relative/~user/config.json":         "relative/~user/config.json",
+		filepath.Join("testdata", "app.log"): filepath.Join("testdata", "app.log"),
+	} {
+		expanded, err := file.ExpandPath(path)
+		require.NoError(t, err)
+		assert.Equal(t, expected, expanded, path)
+	}
+}
+
+func TestWithExpandPath(t *testing.T) {
+	t.Setenv("GO_FILE_TEST_DIR", t.TempDir())
+
+	f := file.NewWriter("$GO_FILE_TEST_DIR/logs/app.log", file.WithExpandPath())
+	_, err := f.Write([]byte("Hello, World!"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	expanded := filepath.Join(os.Getenv("GO_FILE_TEST_DIR"), "logs", "app.log")
+	assert.Equal(t, expanded, f.Writer.FilePath)
+
+	f = file.New("${GO_FILE_TEST_DIR}/logs/app.log", file.WithExpandPath())
+	cnt, err := f.Read()
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, World!", string(cnt))
+	assert.Equal(t, expanded, f.FilePath)
+	require.NoError(t, f.Close())
+}
+
+func TestWithExpandPathConstructors(t *testing.T) {
+	t.Setenv("GO_FILE_TEST_DIR", t.TempDir())
+	expanded := filepath.Join(os.Getenv("GO_FILE_TEST_DIR"), "app.log")
+
+	f := file.NewAppendWriter("$GO_FILE_TEST_DIR/app.log", file.WithExpandPath())
+	assert.Equal(t, expanded, f.FilePath)
+	_, err := f.Write([]byte("Hello, World!"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	require.NoError(t, file.Touch("$GO_FILE_TEST_DIR/touched", file.WithExpandPath()))
+	assert.FileExists(t, filepath.Join(os.Getenv("GO_FILE_TEST_DIR"), "touched"))
+
+	// Variables can't smuggle a path past the check of NewSafeWriter
+	t.Setenv("GO_FILE_TEST_ESCAPE", "../escape.txt")
+	_, err = file.NewSafeWriter(t.TempDir(), "$GO_FILE_TEST_ESCAPE", file.WithExpandPath())
+	require.ErrorIs(t, err, file.ErrUnsafePath)
+}
@@ -0,0 +1,61 @@
+package file
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// errAllDestinationsFailed stops a fan-out copy once no destination is left.
+var errAllDestinationsFailed = errors.New("all destinations failed")
+
+// fanoutWriter writes to all destinations and, unlike io.MultiWriter, keeps
+// writing to the remaining ones when one of them fails.
+type fanoutWriter struct {
+	dsts []*File
+	errs []error
+}
+
+func (w *fanoutWriter) Write(p []byte) (int, error) {
+	alive := 0
+	for i, dst := range w.dsts {
+		if w.errs[i] != nil {
+			continue
+		}
+		n, err := dst.Write(p)
+		if err == nil && n < len(p) {
+			err = io.ErrShortWrite
+		}
+		if err != nil {
+			w.errs[i] = fmt.Errorf("failed to write to %q: %w", dst.path(), err)
+			continue
+		}
+		alive++
+	}
+	if alive == 0 {
+		return 0, errAllDestinationsFailed
+	}
+	return len(p), nil
+}
+
+// CopyToAll reads the file once and writes its content to all destinations. A
+// failing destination doesn't stop the copy to the others, its error is joined
+// into the returned error. The returned count is the number of bytes read.
+// The destinations are not closed.
+func (f *File) CopyToAll(dsts ...*File) (int64, error) {
+	reader, err := f.lazyReader()
+	if err != nil {
+		return 0, err
+	}
+	w := &fanoutWriter{dsts: dsts, errs: make([]error, len(dsts))}
+	if len(dsts) == 0 {
+		return io.Copy(io.Discard, reader)
+	}
+	n, err := io.Copy(w, reader)
+	if errors.Is(err, errAllDestinationsFailed) {
+		err = nil
+	} else if err != nil {
+		err = fmt.Errorf("failed to read %q: %w", f.FilePath, err)
+	}
+	return n, errors.Join(append([]error{err}, w.errs...)...)
+}
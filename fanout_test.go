@@ -0,0 +1,67 @@
+package file_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// @markdown
+// TestCopyToAll illustrates how to copy a file to several destinations while reading it only once.
+func TestCopyToAll(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	src := file.New(createFile(t, strings.Repeat("Hello, World!", 10000)))
+	dsts := []*file.File{
+		file.NewWriter(filepath.Join(dir, "a", "copy.txt")),
+		file.NewWriter(filepath.Join(dir, "b", "copy.txt")),
+	}
+
+	n, err := src.CopyToAll(dsts...)
+	require.NoError(t, err)
+	assert.Equal(t, int64(130000), n)
+
+	for _, dst := range dsts {
+		require.NoError(t, dst.Close())
+		cnt, err := os.ReadFile(dst.Writer.FilePath)
+		require.NoError(t, err)
+		assert.Equal(t, strings.Repeat("Hello, World!", 10000), string(cnt))
+	}
+}
+
+func TestCopyToAllPartialFailure(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	src := file.New(createFile(t, "Hello, World!"))
+	good := file.NewWriter(filepath.Join(dir, "good.txt"))
+
+	n, err := src.CopyToAll(file.NewWriterError(os.ErrPermission), good)
+	require.ErrorIs(t, err, os.ErrPermission)
+	assert.Equal(t, int64(13), n)
+
+	require.NoError(t, good.Close())
+	cnt, err := os.ReadFile(filepath.Join(dir, "good.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, World!", string(cnt))
+}
+
+func TestCopyToAllErrors(t *testing.T) {
+	t.Parallel()
+	_, err := file.New("nonexistent.txt").CopyToAll(file.NewWriter(filepath.Join(t.TempDir(), "copy.txt")))
+	assert.True(t, os.IsNotExist(err))
+
+	src := file.New(createFile(t, "Hello, World!"))
+	_, err = src.CopyToAll(file.NewWriterError(os.ErrPermission), file.NewWriterError(os.ErrClosed))
+	require.ErrorIs(t, err, os.ErrPermission)
+	require.ErrorIs(t, err, os.ErrClosed)
+
+	n, err := file.New(createFile(t, "Hello, World!")).CopyToAll()
+	require.NoError(t, err)
+	assert.Equal(t, int64(13), n)
+}
@@ -0,0 +1,186 @@
+package file
+
+import (
+	"container/list"
+	"errors"
+	"io"
+	"os"
+	"sync"
+)
+
+// defaultMaxOpenFiles is the default capacity of the package-level handle pool.
+const defaultMaxOpenFiles = 512
+
+var defaultFDPool = newFDPool(defaultMaxOpenFiles)
+
+// SetMaxOpenFiles sets how many file handles the readers created with
+// WithManagedFD may keep open at the same time. When the limit is reached the
+// least recently used handle is closed and transparently reopened on its next
+// use. The limit is soft, handles busy reading are not closed.
+func SetMaxOpenFiles(n int) {
+	defaultFDPool.setMax(n)
+}
+
+// fdPool keeps track of the open handles of managed readers in LRU order.
+type fdPool struct {
+	mu  sync.Mutex
+	max int
+	lru *list.List
+}
+
+func newFDPool(maxOpen int) *fdPool {
+	return &fdPool{max: max(maxOpen, 1), lru: list.New()}
+}
+
+func (p *fdPool) setMax(n int) {
+	p.mu.Lock()
+	p.max = max(n, 1)
+	p.mu.Unlock()
+}
+
+// touch marks r as most recently used and returns the readers whose handles
+// have to be closed to stay within the limit.
+func (p *fdPool) touch(r *managedReader) []*managedReader {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if r.elem != nil {
+		p.lru.MoveToFront(r.elem)
+	} else {
+		r.elem = p.lru.PushFront(r)
+	}
+	var victims []*managedReader
+	for p.lru.Len() > p.max {
+		back := p.lru.Back()
+		victim, _ := p.lru.Remove(back).(*managedReader)
+		victim.elem = nil
+		victims = append(victims, victim)
+	}
+	return victims
+}
+
+func (p *fdPool) remove(r *managedReader) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if r.elem != nil {
+		p.lru.Remove(r.elem)
+		r.elem = nil
+	}
+}
+
+// managedReader is a file reader whose handle may be closed by its pool while
+// idle. It remembers the read offset and reopens the file at that offset when
+// used again. If the file is replaced or truncated while the handle is closed
+// the reader continues at the same offset of the new file.
+type managedReader struct {
+	mu     sync.Mutex
+	path   string
+	cfg    *config
+	pool   *fdPool
+	fh     *os.File
+	offset int64
+	closed bool
+	elem   *list.Element
+}
+
+func openManaged(path string, cfg *config) (*managedReader, error) {
+	fh, err := cfg.open(path)
+	if err != nil {
+		return nil, err
+	}
+	r := &managedReader{path: path, cfg: cfg, pool: cfg.fdPool, fh: fh}
+	r.release(r.pool.touch(r))
+	return r, nil
+}
+
+// handle returns the open file handle, reopening it if it was evicted.
+// The caller must hold r.mu.
+func (r *managedReader) handle() (*os.File, error) {
+	if r.closed {
+		return nil, os.ErrClosed
+	}
+	if r.fh == nil {
+		fh, err := r.cfg.open(r.path)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := fh.Seek(r.offset, io.SeekStart); err != nil {
+			return nil, errors.Join(err, fh.Close())
+		}
+		r.fh = fh
+	}
+	return r.fh, nil
+}
+
+// release closes the handles of the evicted readers. Victims that are busy, e.g.
+// in the middle of a Read, keep their handle and rejoin the pool when done.
+func (r *managedReader) release(victims []*managedReader) {
+	for _, victim := range victims {
+		if victim == r || !victim.mu.TryLock() {
+			continue
+		}
+		if victim.fh != nil {
+			_ = victim.fh.Close()
+			victim.fh = nil
+		}
+		victim.mu.Unlock()
+	}
+}
+
+func (r *managedReader) Read(p []byte) (int, error) {
+	r.mu.Lock()
+	fh, err := r.handle()
+	if err != nil {
+		r.mu.Unlock()
+		return 0, err
+	}
+	n, err := fh.Read(p)
+	r.offset += int64(n)
+	r.mu.Unlock()
+	r.release(r.pool.touch(r))
+	return n, err
+}
+
+func (r *managedReader) ReadAt(p []byte, off int64) (int, error) {
+	r.mu.Lock()
+	fh, err := r.handle()
+	if err != nil {
+		r.mu.Unlock()
+		return 0, err
+	}
+	n, err := fh.ReadAt(p, off)
+	r.mu.Unlock()
+	r.release(r.pool.touch(r))
+	return n, err
+}
+
+func (r *managedReader) Seek(offset int64, whence int) (int64, error) {
+	r.mu.Lock()
+	fh, err := r.handle()
+	if err != nil {
+		r.mu.Unlock()
+		return 0, err
+	}
+	pos, err := fh.Seek(offset, whence)
+	if err == nil {
+		r.offset = pos
+	}
+	r.mu.Unlock()
+	r.release(r.pool.touch(r))
+	return pos, err
+}
+
+func (r *managedReader) Close() error {
+	r.pool.remove(r)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return os.ErrClosed
+	}
+	r.closed = true
+	if r.fh == nil {
+		return nil
+	}
+	err := r.fh.Close()
+	r.fh = nil
+	return err
+}
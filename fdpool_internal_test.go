@@ -0,0 +1,145 @@
+package file
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManagedReaderEviction(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	pool := newFDPool(2)
+	withPool := func(c *config) {
+		c.fdPool = pool
+	}
+
+	files := make([]*File, 3)
+	readers := make([]*managedReader, 3)
+	for i := range files {
+		filePath := filepath.Join(dir, string(rune('a'+i)))
+		require.NoError(t, os.WriteFile(filePath, []byte("0123456789"), 0o600))
+		files[i] = New(filePath, withPool)
+		reader, err := files[i].lazyReader()
+		require.NoError(t, err)
+		managed, ok := reader.(*managedReader)
+		require.True(t, ok)
+		readers[i] = managed
+	}
+
+	// Opening the third file evicted the handle of the least recently used one
+	assert.Nil(t, readers[0].fh)
+	assert.NotNil(t, readers[1].fh)
+	assert.NotNil(t, readers[2].fh)
+	assert.Equal(t, 2, pool.lru.Len())
+
+	p := make([]byte, 4)
+	for _, r := range readers {
+		_, err := io.ReadFull(r, p)
+		require.NoError(t, err)
+		assert.Equal(t, "0123", string(p))
+	}
+
+	// Reading continues at the remembered offset after the handle was reopened
+	for _, r := range readers {
+		_, err := io.ReadFull(r, p)
+		require.NoError(t, err)
+		assert.Equal(t, "4567", string(p))
+	}
+
+	for _, f := range files {
+		require.NoError(t, f.Close())
+	}
+	assert.Equal(t, 0, pool.lru.Len())
+}
+
+func TestManagedReaderEvictionBusy(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	pool := newFDPool(1)
+	withPool := func(c *config) {
+		c.fdPool = pool
+	}
+	for _, name := range []string{"a", "b"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("0123456789"), 0o600))
+	}
+	reader, err := New(filepath.Join(dir, "a"), withPool).lazyReader()
+	require.NoError(t, err)
+	busy, ok := reader.(*managedReader)
+	require.True(t, ok)
+
+	// A reader busy reading keeps its handle when it gets evicted
+	busy.mu.Lock()
+	other := New(filepath.Join(dir, "b"), withPool)
+	_, err = other.lazyReader()
+	require.NoError(t, err)
+	assert.NotNil(t, busy.fh)
+	busy.mu.Unlock()
+
+	p := make([]byte, 4)
+	_, err = io.ReadFull(busy, p)
+	require.NoError(t, err)
+	assert.Equal(t, "0123", string(p))
+	require.NoError(t, busy.Close())
+	require.NoError(t, other.Close())
+}
+
+func TestManagedReaderConcurrentEviction(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	pool := newFDPool(1)
+	withPool := func(c *config) {
+		c.fdPool = pool
+	}
+
+	// Every read evicts the handles of the other readers
+	var wg sync.WaitGroup
+	for i := range 10 {
+		filePath := filepath.Join(dir, string(rune('a'+i)))
+		require.NoError(t, os.WriteFile(filePath, []byte(strings.Repeat("Hello, World!\n", 100)), 0o600))
+		wg.Go(func() {
+			f := New(filePath, withPool)
+			defer f.Close()
+			reader, err := f.lazyReader()
+			if !assert.NoError(t, err) {
+				return
+			}
+			cnt, err := io.ReadAll(iotest.OneByteReader(reader))
+			assert.NoError(t, err)
+			assert.Equal(t, strings.Repeat("Hello, World!\n", 100), string(cnt))
+		})
+	}
+	wg.Wait()
+	assert.Equal(t, 0, pool.lru.Len())
+}
+
+func TestManagedReaderConcurrent(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	pool := newFDPool(3)
+	withPool := func(c *config) {
+		c.fdPool = pool
+	}
+
+	var wg sync.WaitGroup
+	for i := range 10 {
+		filePath := filepath.Join(dir, string(rune('a'+i)))
+		require.NoError(t, os.WriteFile(filePath, []byte("Hello, World!"), 0o600))
+		wg.Go(func() {
+			f := New(filePath, withPool)
+			defer f.Close()
+			cnt, err := f.Read()
+			assert.NoError(t, err)
+			assert.Equal(t, "Hello, World!", string(cnt))
+		})
+	}
+	wg.Wait()
+	assert.Equal(t, 0, pool.lru.Len())
+}
@@ -0,0 +1,40 @@
+package file_test
+
+import (
+	"io"
+	"os"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithManagedFD(t *testing.T) {
+	t.Parallel()
+	f := file.New(createFile(t, "Hello, World!"), file.WithManagedFD())
+
+	exists, err := f.Exists()
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	_, err = f.Seek(7, io.SeekStart)
+	require.NoError(t, err)
+
+	cnt, err := f.Read()
+	require.NoError(t, err)
+	assert.Equal(t, "World!", string(cnt))
+
+	require.NoError(t, f.Close())
+	require.ErrorIs(t, f.Close(), os.ErrClosed)
+}
+
+func TestWithManagedFDNonExistingFile(t *testing.T) {
+	t.Parallel()
+	f := file.New("nonexistent.txt", file.WithManagedFD())
+
+	exists, err := f.Exists()
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
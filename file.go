@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+	"sync/atomic"
 )
 
 type (
@@ -18,6 +19,8 @@ type (
 		FileName  string
 		FilePath  string
 		io.Writer
+
+		written int64
 	}
 
 	File struct {
@@ -27,20 +30,58 @@ type (
 
 		reader ReaderFunc
 		writer WriterFunc
+		cfg    *config
+
+		// mu serializes the writes, the other uses of the writer and its
+		// creation on first use.
+		mu sync.Mutex
+		// recordsEnd is the offset behind the records verified by AppendRecord.
+		recordsEnd int64
 	}
 )
 
-func readerFunc(filePath string) func() (io.Reader, error) {
+func readerFunc(filePath string, cfg *config) func() (io.Reader, error) {
 	return func() (io.Reader, error) {
-		file, err := os.Open(filePath)
-		return file, err
+		var file io.Reader
+		var err error
+		switch {
+		case cfg.fsys != nil:
+			file, err = cfg.fsys.Open(filePath)
+		case cfg.fdPool != nil:
+			file, err = openManaged(filePath, cfg)
+		default:
+			file, err = retry(cfg, func() (*os.File, error) {
+				return cfg.open(filePath)
+			})
+		}
+		if err != nil {
+			return nil, err
+		}
+		return cfg.wrapReader(filePath, file)
+	}
+}
+
+// newFile returns the File for filePath that reads and writes as configured.
+// filePath is expanded first if WithExpandPath is set.
+func (c *config) newFile(filePath string) *File {
+	filePath, err := c.expandPath(filePath)
+	if err != nil {
+		return newErrorFile(err)
+	}
+	return &File{
+		FilePath: filePath,
+		reader:   sync.OnceValues(readerFunc(filePath, c)),
+		writer:   sync.OnceValue(writerFunc(filePath, c)),
+		cfg:      c,
 	}
 }
 
 type OpenFunc = func(string) *File
 
 func Open() func(string) *File {
-	return New
+	return func(filePath string) *File {
+		return New(filePath)
+	}
 }
 
 func OpenFile(f *File) func(string) *File {
@@ -50,12 +91,8 @@ func OpenFile(f *File) func(string) *File {
 	}
 }
 
-func New(filePath string) *File {
-	return &File{
-		FilePath: filePath,
-		reader:   sync.OnceValues(readerFunc(filePath)),
-		writer:   sync.OnceValue(writerFunc(filePath)),
-	}
+func New(filePath string, opts ...Option) *File {
+	return newConfig(opts).newFile(filePath)
 }
 
 func NewReader(reader io.Reader) *File {
@@ -77,31 +114,40 @@ func NewReaderError(err error) *File {
 	return f
 }
 
-func writerFunc(filePath string) func() func() (*Writer, error) {
+func writerFunc(filePath string, cfg *config) func() func() (*Writer, error) {
 	return func() func() (*Writer, error) {
 		// Ensure the directory exists
-		dir := filepath.Dir(filePath)
-		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		if err := cfg.mkdirParent(filePath); err != nil {
 			return func() (*Writer, error) {
-				return nil, fmt.Errorf("failed to create directory %q: %w", dir, err)
+				return nil, err
 			}
 		}
+		dir := filepath.Dir(filePath)
 		return func() (*Writer, error) {
 			fileName := filepath.Base(filePath)
-			file, err := os.Create(filePath)
+			file, err := cfg.openWriter(filePath)
 			if err != nil {
-				return nil, fmt.Errorf("failed to create file: %w", err)
+				return nil, fmt.Errorf("%w: %w", ErrCreateFile, err)
 			}
-			return &Writer{Directory: dir, FileName: fileName, FilePath: filePath, Writer: file}, nil
+			w, err := cfg.wrapWriter(filePath, file)
+			if err != nil {
+				return nil, errors.Join(err, discard(file))
+			}
+			return &Writer{Directory: dir, FileName: fileName, FilePath: filePath, Writer: w}, nil
 		}
 	}
 }
 
-func NewWriter(filePath string) *File {
-	return &File{
-		reader: sync.OnceValues(readerFunc(filePath)),
-		writer: sync.OnceValue(writerFunc(filePath)),
-	}
+func NewWriter(filePath string, opts ...Option) *File {
+	return newConfig(opts).newFile(filePath)
+}
+
+// NewAppendWriter returns a File like NewWriter that appends to an existing file
+// instead of truncating it.
+func NewAppendWriter(filePath string, opts ...Option) *File {
+	cfg := newConfig(opts)
+	cfg.appendMode = true
+	return cfg.newFile(filePath)
 }
 
 func NewWriterBuffer(w io.Writer, filePath string) *File {
@@ -116,6 +162,13 @@ func NewWriterBuffer(w io.Writer, filePath string) *File {
 	})}
 }
 
+// NewDryRunWriter returns a File whose writes succeed as if they went to
+// filePath, but are discarded. Unlike NewWriter it never creates the file or its
+// directory, the Writer fields are still set for inspection.
+func NewDryRunWriter(filePath string) *File {
+	return NewWriterBuffer(io.Discard, filePath)
+}
+
 func NewWriterError(err error) *File {
 	//nolint:unparam // the param *Writer is only needed to satisfy the func interface
 	writer := func() (*Writer, error) {
@@ -126,22 +179,162 @@ func NewWriterError(err error) *File {
 	})}
 }
 
+// newErrorFile returns a File whose reader and writer both fail with err.
+func newErrorFile(err error) *File {
+	f := NewReaderError(err)
+	f.writer = NewWriterError(err).writer
+	return f
+}
+
+// Exists reports whether the file exists. Files with a path are checked with
+// os.Stat without side effects on Reader, no file handle is opened. Files
+// without a path report whether their reader can be opened and keep it for the
+// next read, Close closes it. Writer-only Files like those of NewWriterBuffer
+// report false.
 func (f *File) Exists() (bool, error) {
-	if f.Reader == nil {
-		reader, err := f.reader()
+	if filePath := f.path(); filePath != "" {
+		_, err := f.config().stat(filePath)
 		if err != nil {
 			if os.IsNotExist(err) {
-				f.reader = sync.OnceValues(readerFunc(f.FilePath))
 				return false, nil
 			}
 			return false, err
 		}
-		f.Reader = reader
+		return true, nil
+	}
+	if f.reader == nil {
+		return false, nil
+	}
+	if f.Reader != nil {
+		return true, nil
+	}
+	// The opened reader is kept for the next read and closed by Close.
+	reader, err := f.reader()
+	if err != nil {
+		if os.IsNotExist(err) {
+			f.reader = sync.OnceValues(readerFunc(f.FilePath, f.config()))
+			return false, nil
+		}
+		return false, err
 	}
+	f.Reader = reader
 	return true, nil
 }
 
+// Reset rewinds the file so the next read starts at the beginning again. Files
+// with a path close their reader and open it again on the next read. In-memory
+// readers are seeked to the start and fail with ErrNotSeekable if they don't
+// implement io.Seeker.
+func (f *File) Reset() error {
+	if filePath := f.path(); filePath != "" {
+		if err := f.closeReader(); err != nil {
+			return fmt.Errorf("failed to close reader: %w", err)
+		}
+		f.reader = sync.OnceValues(readerFunc(filePath, f.config()))
+		return nil
+	}
+	if f.Reader == nil {
+		return nil
+	}
+	seeker, ok := f.Reader.(io.Seeker)
+	if !ok {
+		return fmt.Errorf("failed to reset reader of type %T: %w", f.Reader, ErrNotSeekable)
+	}
+	_, err := seeker.Seek(0, io.SeekStart)
+	return err
+}
+
 func (f *File) Read() ([]byte, error) {
+	reader, err := f.lazyReader()
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(reader)
+}
+
+// ReadByteStream returns the reader of the file as an io.ReadCloser for
+// streaming, e.g. with io.Copy, instead of reading it into memory like Read.
+// Errors opening the reader are returned by the first Read. Closing the stream
+// closes the reader of the File.
+func (f *File) ReadByteStream() io.ReadCloser {
+	reader, err := f.lazyReader()
+	if err != nil {
+		reader = errReader{err: err}
+	}
+	return readCloser{Reader: reader, Closer: closeFunc(f.closeReader)}
+}
+
+// ReadCloser opens the reader of the file like Read and hands it over to the
+// caller, e.g. for APIs that take ownership of an io.ReadCloser. Closing it
+// closes the file, File.Close no longer does, and following reads of the File
+// fail with os.ErrClosed. The method can't be named Reader, as that is the name
+// of the field holding the opened reader.
+func (f *File) ReadCloser() (io.ReadCloser, error) {
+	reader, err := f.lazyReader()
+	if err != nil {
+		return nil, err
+	}
+	f.Reader = nil
+	f.reader = func() (io.Reader, error) {
+		return nil, fmt.Errorf("reader was handed over by ReadCloser: %w", os.ErrClosed)
+	}
+	return readCloser{Reader: reader, Closer: closeFunc(func() error {
+		return closeIfCloser(reader)
+	})}, nil
+}
+
+// errReader fails every Read with err.
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
+
+// closeFunc adapts a func to io.Closer.
+type closeFunc func() error
+
+func (fn closeFunc) Close() error {
+	return fn()
+}
+
+// ReadString returns the content of the file as a string.
+func (f *File) ReadString() (string, error) {
+	cnt, err := f.Read()
+	if err != nil {
+		return "", err
+	}
+	return string(cnt), nil
+}
+
+// WriteTo implements the io.WriterTo interface by streaming the content of the
+// file to w without buffering it like Read does.
+func (f *File) WriteTo(w io.Writer) (int64, error) {
+	reader, err := f.lazyReader()
+	if err != nil {
+		return 0, err
+	}
+	return io.Copy(w, reader)
+}
+
+// Seek implements the io.Seeker interface by seeking the reader, which is opened
+// on first use like in Read. It fails with ErrNotSeekable if the reader does not
+// implement io.Seeker.
+func (f *File) Seek(offset int64, whence int) (int64, error) {
+	reader, err := f.lazyReader()
+	if err != nil {
+		return 0, err
+	}
+	seeker, ok := reader.(io.Seeker)
+	if !ok {
+		return 0, fmt.Errorf("reader of type %T: %w", reader, ErrNotSeekable)
+	}
+	return seeker.Seek(offset, whence)
+}
+
+// lazyReader opens the reader on first use and returns it.
+func (f *File) lazyReader() (io.Reader, error) {
 	if f.Reader == nil {
 		reader, err := f.reader()
 		if err != nil {
@@ -149,26 +342,169 @@ func (f *File) Read() ([]byte, error) {
 		}
 		f.Reader = reader
 	}
-	return io.ReadAll(f.Reader)
+	return f.Reader, nil
 }
 
-// Write implements the io.Writer interface.
+// Write implements the io.Writer interface. It is safe for concurrent use, each
+// p is written as a whole without interleaving with other writes.
 func (f *File) Write(p []byte) (n int, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w, err := f.lazyWriter()
+	if err != nil {
+		if errors.Is(err, ErrNilWriter) {
+			return -1, err
+		}
+		return 0, err
+	}
+	return w.Write(p)
+}
+
+// WriteString implements the io.StringWriter interface. It avoids converting s
+// to a byte slice if the underlying writer implements io.StringWriter. Like
+// Write it is safe for concurrent use.
+func (f *File) WriteString(s string) (n int, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w, err := f.lazyWriter()
+	if err != nil {
+		if errors.Is(err, ErrNilWriter) {
+			return -1, err
+		}
+		return 0, err
+	}
+	return w.WriteString(s)
+}
+
+// WriteLine writes s followed by a newline in a single write, so concurrent
+// writers don't split the line from its newline. It returns the number of bytes
+// written including the newline.
+func (f *File) WriteLine(s string) (int, error) {
+	return f.WriteString(s + "\n")
+}
+
+// ReadFrom implements the io.ReaderFrom interface by copying r into the file,
+// which is created on first use like in Write. Other writes wait until r is
+// copied.
+func (f *File) ReadFrom(r io.Reader) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w, err := f.lazyWriter()
+	if err != nil {
+		return 0, err
+	}
+	return w.ReadFrom(r)
+}
+
+// WriteReader copies r into the file like ReadFrom and returns the number of
+// bytes written, e.g. to persist an uploaded body.
+func (f *File) WriteReader(r io.Reader) (int64, error) {
+	return f.ReadFrom(r)
+}
+
+// ReadFrom implements the io.ReaderFrom interface, so io.Copy streams r directly
+// into the underlying writer and can use its fast paths like copy_file_range.
+// Writers that embed *os.File but track their writes, like the atomic and the
+// rotating writer, implement ReadFrom themselves so the fast path doesn't
+// bypass them.
+func (w *Writer) ReadFrom(r io.Reader) (int64, error) {
+	n, err := io.Copy(w.Writer, r)
+	atomic.AddInt64(&w.written, n)
+	if err != nil {
+		return n, fmt.Errorf("failed to copy to %q: %w", w.FilePath, err)
+	}
+	return n, nil
+}
+
+// Write writes p to the underlying writer and counts the written bytes.
+func (w *Writer) Write(p []byte) (int, error) {
+	n, err := w.Writer.Write(p)
+	atomic.AddInt64(&w.written, int64(n))
+	return n, err
+}
+
+// WriteString implements the io.StringWriter interface like Write.
+func (w *Writer) WriteString(s string) (int, error) {
+	n, err := io.WriteString(w.Writer, s)
+	atomic.AddInt64(&w.written, int64(n))
+	return n, err
+}
+
+// BytesWritten returns the number of bytes written so far. With
+// WithRecordPadding the padded records are counted. It is safe to call
+// concurrently with writes and after Close.
+func (w *Writer) BytesWritten() int64 {
+	if padding, ok := w.Writer.(*paddingWriter); ok {
+		return padding.bytesWritten()
+	}
+	return atomic.LoadInt64(&w.written)
+}
+
+// WriteCloser creates the writer of the file like Write and hands it over to the
+// caller, e.g. for encoders like csv.Writer that stream into an io.Writer.
+// Closing it flushes and closes the file, File.Close no longer does, and following
+// writes to the File fail with os.ErrClosed.
+func (f *File) WriteCloser() (io.WriteCloser, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w, err := f.lazyWriter()
+	if err != nil {
+		return nil, err
+	}
+	f.Writer = nil
+	f.writer = func() func() (*Writer, error) {
+		return func() (*Writer, error) {
+			return nil, fmt.Errorf("writer was handed over by WriteCloser: %w", os.ErrClosed)
+		}
+	}
+	return writeCloser{Writer: w, Closer: closeFunc(func() error {
+		if closer, ok := w.Writer.(io.Closer); ok {
+			return closer.Close()
+		}
+		return nil
+	})}, nil
+}
+
+// writeCloser combines a writer with the Close of another value.
+type writeCloser struct {
+	io.Writer
+	io.Closer
+}
+
+// lazyWriter creates the writer on first use and returns it.
+func (f *File) lazyWriter() (*Writer, error) {
 	if f.Writer == nil {
 		fw, err := f.writer()()
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
 		if fw == nil {
-			return -1, errors.New("unexpected Writer is nil")
+			return nil, ErrNilWriter
 		}
 		f.Writer = fw
-		return fw.Write(p)
 	}
-	return f.Writer.Write(p)
+	return f.Writer, nil
+}
+
+// path returns the path of the file, which for writer-only Files is the path of
+// the created writer.
+func (f *File) path() string {
+	if f.FilePath == "" && f.Writer != nil {
+		return f.Writer.FilePath
+	}
+	return f.FilePath
+}
+
+// Close closes the reader and the writer of the file. Other writes wait until
+// the writer is closed.
+func (f *File) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.close()
 }
 
-func (f *File) Close() (err error) {
+// close closes the reader and the writer, the caller holds f.mu.
+func (f *File) close() (err error) {
 	if f.Reader != nil {
 		if closer, ok := f.Reader.(io.Closer); ok {
 			err = closer.Close()
@@ -25,8 +25,10 @@ func TestNewWriteFailsToCreateFile(t *testing.T) {
 	_, err = fnc()
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to create file")
+	require.ErrorIs(t, err, ErrCreateFile)
 
 	_, err = file.Write([]byte("Hello, World!"))
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to create file")
+	assert.ErrorIs(t, err, ErrCreateFile)
 }
@@ -2,10 +2,13 @@ package file_test
 
 import (
 	"bytes"
+	"encoding/csv"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"testing/iotest"
 
@@ -149,6 +152,287 @@ func TestNewWriter(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestNewAppendWriter(t *testing.T) {
+	t.Parallel()
+	testFilePath := filepath.Join(t.TempDir(), "logs", "output.log")
+
+	f := file.NewAppendWriter(testFilePath)
+	_, err := f.Write([]byte("a"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	f = file.NewAppendWriter(testFilePath)
+	_, err = f.Write([]byte("b"))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Dir(testFilePath), f.Writer.Directory)
+	assert.Equal(t, "output.log", f.Writer.FileName)
+	assert.Equal(t, testFilePath, f.Writer.FilePath)
+
+	cnt, err := f.Read()
+	require.NoError(t, err)
+	assert.Equal(t, "ab", string(cnt))
+	require.NoError(t, f.Close())
+}
+
+func TestReset(t *testing.T) {
+	t.Parallel()
+	for name, f := range map[string]*file.File{
+		"path":      file.New(createFile(t, "Hello, World!")),
+		"in-memory": file.NewReader(strings.NewReader("Hello, World!")),
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			cnt, err := f.Read()
+			require.NoError(t, err)
+			assert.Equal(t, "Hello, World!", string(cnt))
+
+			require.NoError(t, f.Reset())
+
+			cnt, err = f.Read()
+			require.NoError(t, err)
+			assert.Equal(t, "Hello, World!", string(cnt))
+			require.NoError(t, f.Close())
+		})
+	}
+}
+
+func TestResetError(t *testing.T) {
+	t.Parallel()
+	f := file.NewReader(io.MultiReader(strings.NewReader("Hello, World!")))
+	_, err := f.Read()
+	require.NoError(t, err)
+
+	err = f.Reset()
+	require.ErrorIs(t, err, file.ErrNotSeekable)
+}
+
+func TestReadString(t *testing.T) {
+	t.Parallel()
+	f := file.New(createFile(t, "Hello, World!"))
+
+	s, err := f.ReadString()
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, World!", s)
+	require.NoError(t, f.Close())
+
+	s, err = file.New("nonexistent.txt").ReadString()
+	assert.True(t, os.IsNotExist(err))
+	assert.Empty(t, s)
+}
+
+func TestReadByteStream(t *testing.T) {
+	t.Parallel()
+	f := file.New(createFile(t, "Hello, World!"))
+
+	var buf bytes.Buffer
+	stream := f.ReadByteStream()
+	n, err := io.Copy(&buf, stream)
+	require.NoError(t, err)
+	assert.Equal(t, int64(13), n)
+	assert.Equal(t, "Hello, World!", buf.String())
+	require.NoError(t, stream.Close())
+	require.NoError(t, f.Close())
+
+	stream = file.New("nonexistent.txt").ReadByteStream()
+	_, err = io.Copy(&buf, stream)
+	assert.True(t, os.IsNotExist(err))
+	require.NoError(t, stream.Close())
+}
+
+func TestReadCloser(t *testing.T) {
+	t.Parallel()
+	f := file.New(createFile(t, "Hello, World!"))
+
+	rc, err := f.ReadCloser()
+	require.NoError(t, err)
+	cnt, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, World!", string(cnt))
+	require.NoError(t, rc.Close())
+
+	// The File no longer owns the reader
+	require.NoError(t, f.Close())
+	_, err = f.Read()
+	require.ErrorIs(t, err, os.ErrClosed)
+
+	_, err = file.New("nonexistent.txt").ReadCloser()
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestWriteTo(t *testing.T) {
+	t.Parallel()
+	f := file.New(createFile(t, "Hello, World!"))
+
+	var buf bytes.Buffer
+	n, err := f.WriteTo(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, int64(13), n)
+	assert.Equal(t, "Hello, World!", buf.String())
+	require.NoError(t, f.Close())
+
+	_, err = file.New("nonexistent.txt").WriteTo(&buf)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestSeek(t *testing.T) {
+	t.Parallel()
+	testFilePath := createFile(t, "0123456789abcdefghij")
+
+	f := file.New(testFilePath)
+	offset, err := f.Seek(10, io.SeekStart)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), offset)
+
+	cnt, err := f.Read()
+	require.NoError(t, err)
+	assert.Equal(t, "abcdefghij", string(cnt))
+	require.NoError(t, f.Close())
+
+	_, err = file.NewReader(io.MultiReader(strings.NewReader("x"))).Seek(0, io.SeekStart)
+	require.ErrorIs(t, err, file.ErrNotSeekable)
+
+	_, err = file.New("nonexistent.txt").Seek(0, io.SeekStart)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestWriteString(t *testing.T) {
+	t.Parallel()
+	testFilePath := filepath.Join(t.TempDir(), "output.log")
+
+	f := file.NewWriter(testFilePath)
+	n, err := f.WriteString("Hello, World!")
+	require.NoError(t, err)
+	assert.Equal(t, len("Hello, World!"), n)
+	require.NoError(t, f.Close())
+
+	cnt, err := os.ReadFile(testFilePath)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, World!", string(cnt))
+
+	n, err = file.NewWriterError(nil).WriteString("Hello, World!")
+	require.ErrorContains(t, err, "unexpected Writer is nil")
+	require.ErrorIs(t, err, file.ErrNilWriter)
+	assert.Equal(t, -1, n)
+}
+
+func TestWriteLine(t *testing.T) {
+	t.Parallel()
+	testFilePath := filepath.Join(t.TempDir(), "output.log")
+
+	f := file.NewWriter(testFilePath)
+	n, err := f.WriteLine("started")
+	require.NoError(t, err)
+	assert.Equal(t, len("started\n"), n)
+	_, err = f.WriteLine("stopped")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	cnt, err := os.ReadFile(testFilePath)
+	require.NoError(t, err)
+	assert.Equal(t, "started\nstopped\n", string(cnt))
+}
+
+func TestWriteCloser(t *testing.T) {
+	t.Parallel()
+	testFilePath := filepath.Join(t.TempDir(), "users.csv")
+	f := file.NewWriter(testFilePath)
+
+	wc, err := f.WriteCloser()
+	require.NoError(t, err)
+	cw := csv.NewWriter(wc)
+	require.NoError(t, cw.WriteAll([][]string{{"name", "age"}, {"Alice", "30"}}))
+	require.NoError(t, wc.Close())
+
+	// The File no longer owns the writer
+	require.NoError(t, f.Close())
+	_, err = f.Write([]byte("Bob,40\n"))
+	require.ErrorIs(t, err, os.ErrClosed)
+
+	cnt, err := os.ReadFile(testFilePath)
+	require.NoError(t, err)
+	assert.Equal(t, "name,age\nAlice,30\n", string(cnt))
+
+	_, err = file.NewWriterError(os.ErrPermission).WriteCloser()
+	require.ErrorIs(t, err, os.ErrPermission)
+}
+
+func TestWriteConcurrent(t *testing.T) {
+	t.Parallel()
+	testFilePath := filepath.Join(t.TempDir(), "output.log")
+
+	// Buffered writes are not safe for concurrent use on their own
+	f := file.NewBufferedWriter(testFilePath, 100)
+	var wg sync.WaitGroup
+	for i := range 50 {
+		wg.Go(func() {
+			record := fmt.Sprintf("%02d%s\n", i, strings.Repeat("x", 61))
+			for range 20 {
+				_, err := f.Write([]byte(record))
+				assert.NoError(t, err)
+			}
+		})
+	}
+	wg.Wait()
+	require.NoError(t, f.Close())
+
+	cnt, err := os.ReadFile(testFilePath)
+	require.NoError(t, err)
+	require.Len(t, cnt, 50*20*64)
+	counts := map[string]int{}
+	for record := range bytes.Lines(cnt) {
+		require.Equal(t, strings.Repeat("x", 61)+"\n", string(record[2:]))
+		counts[string(record[:2])]++
+	}
+	assert.Len(t, counts, 50)
+	for id, n := range counts {
+		assert.Equal(t, 20, n, id)
+	}
+}
+
+func TestReadFrom(t *testing.T) {
+	t.Parallel()
+	testFilePath := filepath.Join(t.TempDir(), "copy", "output.log")
+
+	f := file.NewWriter(testFilePath)
+	n, err := io.Copy(f, strings.NewReader("Hello, World!"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(13), n)
+	require.NoError(t, f.Close())
+
+	cnt, err := os.ReadFile(testFilePath)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, World!", string(cnt))
+}
+
+func TestWriteReader(t *testing.T) {
+	t.Parallel()
+	testFilePath := filepath.Join(t.TempDir(), "uploads", "body.txt")
+
+	f := file.NewWriter(testFilePath)
+	n, err := f.WriteReader(strings.NewReader("Hello, World!"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(13), n)
+	require.NoError(t, f.Close())
+
+	cnt, err := os.ReadFile(testFilePath)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, World!", string(cnt))
+}
+
+func TestReadFromError(t *testing.T) {
+	t.Parallel()
+	testFilePath := filepath.Join(t.TempDir(), "output.log")
+
+	f := file.NewWriter(testFilePath)
+	_, err := f.ReadFrom(iotest.ErrReader(io.ErrUnexpectedEOF))
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	assert.ErrorContains(t, err, testFilePath)
+	require.NoError(t, f.Close())
+
+	_, err = file.NewWriterError(os.ErrPermission).ReadFrom(strings.NewReader("x"))
+	require.ErrorIs(t, err, os.ErrPermission)
+}
+
 func TestFileExistFalse(t *testing.T) {
 	t.Parallel()
 	// Create a File instance with a non-existent file
@@ -174,6 +458,60 @@ func TestReadError(t *testing.T) {
 	assert.Equal(t, io.EOF, err)
 }
 
+func TestFileExistNoSideEffect(t *testing.T) {
+	t.Parallel()
+	tmpFile := createFile(t, "old")
+
+	f := file.New(tmpFile)
+	exists, err := f.Exists()
+	require.NoError(t, err)
+	assert.True(t, exists)
+	assert.Nil(t, f.Reader)
+
+	// Had Exists opened a handle, Read would still see the replaced file
+	replacement := createFile(t, "new")
+	require.NoError(t, os.Rename(replacement, tmpFile))
+
+	cnt, err := f.Read()
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(cnt))
+	require.NoError(t, f.Close())
+}
+
+func TestFileExistWriterOnly(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	exists, err := file.NewWriterBuffer(&buf, "").Exists()
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	exists, err = file.NewWriterError(os.ErrPermission).Exists()
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	f := file.NewWriter(filepath.Join(t.TempDir(), "output.log"))
+	_, err = f.Write([]byte("Hello, World!"))
+	require.NoError(t, err)
+	exists, err = f.Exists()
+	require.NoError(t, err)
+	assert.True(t, exists)
+	assert.Nil(t, f.Reader)
+	require.NoError(t, f.Close())
+}
+
+func TestFileExistClose(t *testing.T) {
+	t.Parallel()
+	src := &closeRecorder{Reader: strings.NewReader("Hello")}
+	f := file.NewReader(src)
+
+	// The reader opened by Exists is closed by Close
+	exists, err := f.Exists()
+	require.NoError(t, err)
+	assert.True(t, exists)
+	require.NoError(t, f.Close())
+	assert.True(t, src.closed)
+}
+
 func TestFileExistError(t *testing.T) {
 	t.Parallel()
 	// Create a File instance that always returns an error
@@ -228,6 +566,7 @@ func TestNewWriterComplex(t *testing.T) {
 		_, err = f.Write([]byte{})
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to create directory")
+		assert.ErrorIs(t, err, file.ErrCreateDir)
 	})
 }
 
@@ -248,6 +587,23 @@ func TestNewWriterBuffer(t *testing.T) {
 	assert.Equal(t, filepath.Base(testFilePath), f.Writer.FileName)
 }
 
+func TestNewDryRunWriter(t *testing.T) {
+	t.Parallel()
+	testFilePath := filepath.Join(t.TempDir(), "not_exists", "output.log")
+
+	f := file.NewDryRunWriter(testFilePath)
+	n, err := f.Write([]byte("Hello, World!"))
+	require.NoError(t, err)
+	assert.Equal(t, 13, n)
+	assert.Equal(t, filepath.Dir(testFilePath), f.Writer.Directory)
+	assert.Equal(t, "output.log", f.Writer.FileName)
+	assert.Equal(t, testFilePath, f.Writer.FilePath)
+	require.NoError(t, f.Close())
+
+	_, err = os.Stat(filepath.Dir(testFilePath))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
 func TestNewWriterError(t *testing.T) {
 	t.Parallel()
 	f := file.NewWriterError(os.ErrClosed)
@@ -257,6 +613,7 @@ func TestNewWriterError(t *testing.T) {
 	f = file.NewWriterError(nil)
 	n, err := f.Write([]byte("Hello, World!"))
 	assert.ErrorContains(t, err, "unexpected Writer is nil")
+	assert.ErrorIs(t, err, file.ErrNilWriter)
 	assert.Equal(t, -1, n)
 }
 
@@ -329,3 +686,18 @@ func createFile(t *testing.T, cnt string) (name string) {
 	require.NoError(t, tmpFile.Close())
 	return tmpFile.Name()
 }
+
+func TestBytesWritten(t *testing.T) {
+	t.Parallel()
+	f := file.NewWriter(filepath.Join(t.TempDir(), "output.log"))
+
+	_, err := f.Write([]byte("Hello, "))
+	require.NoError(t, err)
+	_, err = f.WriteString("World")
+	require.NoError(t, err)
+	_, err = f.ReadFrom(strings.NewReader("!"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	assert.Equal(t, int64(13), f.Writer.BytesWritten())
+}
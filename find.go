@@ -0,0 +1,85 @@
+package file
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"regexp/syntax"
+)
+
+const (
+	// findChunkSize is the number of bytes FindSubmatch reads at once.
+	findChunkSize = 64 * 1024
+	// findOverlap is the number of bytes FindSubmatch carries over into the next
+	// chunk so matches spanning two chunks are found.
+	findOverlap = 4 * 1024
+	// findMaxMatch bounds the buffer of a match that keeps growing with every
+	// chunk read.
+	findMaxMatch = 256 * 1024
+)
+
+// FindSubmatch streams the file and returns the first capture group of the first
+// match of re, or ErrNotFound. Only matches up to 4 KiB long are guaranteed to be
+// found when they span two read chunks, and matches longer than 256 KiB fail.
+// Patterns anchored with ^ or \A only match at the start of the file. Other
+// assertions about the text before a match, like (?m)^ or \b, are not supported
+// as they may see the carried over bytes as the start of the text.
+func (f *File) FindSubmatch(re *regexp.Regexp) ([]byte, error) {
+	if re.NumSubexp() < 1 {
+		return nil, fmt.Errorf("regexp %q has no capture group", re)
+	}
+	anchored, err := anchoredAtStart(re)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := f.lazyReader()
+	if err != nil {
+		return nil, err
+	}
+	buf := make([]byte, 0, findChunkSize+findOverlap)
+	chunk := make([]byte, findChunkSize)
+	for {
+		n, err := reader.Read(chunk)
+		buf = append(buf, chunk[:n]...)
+		eof := errors.Is(err, io.EOF)
+		if err != nil && !eof {
+			return nil, fmt.Errorf("failed to read file %q: %w", f.FilePath, err)
+		}
+		loc := re.FindSubmatchIndex(buf)
+		switch {
+		case loc != nil && (eof || loc[1] < len(buf)):
+			if loc[2] < 0 {
+				return []byte{}, nil
+			}
+			return bytes.Clone(buf[loc[2]:loc[3]]), nil
+		case loc != nil && len(buf)-loc[0] > findMaxMatch:
+			return nil, fmt.Errorf("match of %q in %q exceeds %d bytes", re, f.FilePath, findMaxMatch)
+		case loc != nil:
+			// The match reaches the end of the buffer and may continue in the next chunk.
+			buf = append(buf[:0], buf[loc[0]:]...)
+		case eof:
+			return nil, ErrNotFound
+		case len(buf) > findOverlap && anchored:
+			// Once the start of the file is dropped from the buffer it can't match.
+			return nil, ErrNotFound
+		case len(buf) > findOverlap:
+			buf = append(buf[:0], buf[len(buf)-findOverlap:]...)
+		}
+	}
+}
+
+// anchoredAtStart reports whether every match of re has to begin at the start of
+// the text, like for ^ or \A at the start of the pattern.
+func anchoredAtStart(re *regexp.Regexp) (bool, error) {
+	parsed, err := syntax.Parse(re.String(), syntax.Perl)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse regexp %q: %w", re, err)
+	}
+	prog, err := syntax.Compile(parsed.Simplify())
+	if err != nil {
+		return false, fmt.Errorf("failed to compile regexp %q: %w", re, err)
+	}
+	return prog.StartCond()&syntax.EmptyBeginText != 0, nil
+}
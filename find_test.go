@@ -0,0 +1,84 @@
+package file_test
+
+import (
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var versionRe = regexp.MustCompile(`version=(\d+\.\d+\.\d+)`)
+
+func TestFindSubmatch(t *testing.T) {
+	t.Parallel()
+	f := file.New(createFile(t, "name=go-file\nversion=1.2.3\n"))
+
+	version, err := f.FindSubmatch(versionRe)
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.3", string(version))
+}
+
+func TestFindSubmatchAcrossChunks(t *testing.T) {
+	t.Parallel()
+	// The match starts right before the end of the first 64 KiB chunk
+	content := strings.Repeat("x", 64*1024-5) + "version=10.20.30\n" + strings.Repeat("y", 100*1024)
+	f := file.NewReader(io.MultiReader(strings.NewReader(content)))
+
+	version, err := f.FindSubmatch(versionRe)
+	require.NoError(t, err)
+	assert.Equal(t, "10.20.30", string(version))
+}
+
+func TestFindSubmatchAtEOF(t *testing.T) {
+	t.Parallel()
+	f := file.NewReader(strings.NewReader("version=1.2.34"))
+
+	version, err := f.FindSubmatch(versionRe)
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.34", string(version))
+}
+
+func TestFindSubmatchNotFound(t *testing.T) {
+	t.Parallel()
+	f := file.NewReader(strings.NewReader(strings.Repeat("no version here\n", 10000)))
+
+	_, err := f.FindSubmatch(versionRe)
+	require.ErrorIs(t, err, file.ErrNotFound)
+}
+
+func TestFindSubmatchAnchored(t *testing.T) {
+	t.Parallel()
+	anchoredRe := regexp.MustCompile(`^version=(\d+)`)
+
+	// The overlap carried over into the next chunk is not the start of the file
+	content := strings.Repeat("x", 60*1024) + "version=1\n" + strings.Repeat("y", 8*1024)
+	_, err := file.NewReader(strings.NewReader(content)).FindSubmatch(anchoredRe)
+	require.ErrorIs(t, err, file.ErrNotFound)
+
+	version, err := file.NewReader(iotest.OneByteReader(strings.NewReader("version=1\n"))).FindSubmatch(anchoredRe)
+	require.NoError(t, err)
+	assert.Equal(t, "1", string(version))
+}
+
+func TestFindSubmatchTooLong(t *testing.T) {
+	t.Parallel()
+	content := "version=" + strings.Repeat("1", 256*1024+1)
+	_, err := file.NewReader(strings.NewReader(content)).FindSubmatch(regexp.MustCompile(`version=(\d+)`))
+	require.ErrorContains(t, err, "exceeds")
+}
+
+func TestFindSubmatchErrors(t *testing.T) {
+	t.Parallel()
+	_, err := file.New("nonexistent.txt").FindSubmatch(versionRe)
+	assert.True(t, os.IsNotExist(err))
+
+	_, err = file.NewReader(strings.NewReader("version")).FindSubmatch(regexp.MustCompile("version"))
+	assert.ErrorContains(t, err, "no capture group")
+}
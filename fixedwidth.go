@@ -0,0 +1,36 @@
+package file
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FixedWidth splits every line into fields of the given byte widths, trims their
+// trailing spaces and calls fn once per record. Lines shorter than the sum of the
+// widths fail with ErrShortRecord unless WithPadShortLines is given.
+func (f *File) FixedWidth(widths []int, fn func([]string) error, opts ...Option) error {
+	cfg := f.options(opts)
+	total := 0
+	for _, w := range widths {
+		if w < 0 {
+			return fmt.Errorf("invalid field width %d", w)
+		}
+		total += w
+	}
+	lineNo := 0
+	return f.scanLines(cfg, func(line []byte) error {
+		lineNo++
+		if len(line) < total && !cfg.padShortLines {
+			return fmt.Errorf("line %d has %d bytes, expected %d: %w", lineNo, len(line), total, ErrShortRecord)
+		}
+		fields := make([]string, len(widths))
+		offset := 0
+		for i, w := range widths {
+			start := min(offset, len(line))
+			end := min(offset+w, len(line))
+			fields[i] = strings.TrimRight(string(line[start:end]), " ")
+			offset += w
+		}
+		return fn(fields)
+	})
+}
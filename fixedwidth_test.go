@@ -0,0 +1,67 @@
+package file_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixedWidth(t *testing.T) {
+	t.Parallel()
+	f := file.New(createFile(t, "0001John      DOE       \n0002Jane      SMITH     \n"))
+
+	var records [][]string
+	err := f.FixedWidth([]int{4, 10, 10}, func(fields []string) error {
+		records = append(records, fields)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"0001", "John", "DOE"},
+		{"0002", "Jane", "SMITH"},
+	}, records)
+}
+
+func TestFixedWidthShortLine(t *testing.T) {
+	t.Parallel()
+	tmpFile := createFile(t, "0001John      DOE       \n0002Jane\n")
+
+	err := file.New(tmpFile).FixedWidth([]int{4, 10, 10}, func([]string) error {
+		return nil
+	})
+	require.ErrorIs(t, err, file.ErrShortRecord)
+	assert.ErrorContains(t, err, "line 2")
+
+	var records [][]string
+	err = file.New(tmpFile).FixedWidth([]int{4, 10, 10}, func(fields []string) error {
+		records = append(records, fields)
+		return nil
+	}, file.WithPadShortLines())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"0002", "Jane", ""}, records[1])
+}
+
+func TestFixedWidthCallbackError(t *testing.T) {
+	t.Parallel()
+	f := file.New(createFile(t, "0001\n0002\n"))
+
+	calls := 0
+	err := f.FixedWidth([]int{4}, func([]string) error {
+		calls++
+		return errors.ErrUnsupported
+	})
+	require.ErrorIs(t, err, errors.ErrUnsupported)
+	assert.Equal(t, 1, calls)
+}
+
+func TestFixedWidthReadError(t *testing.T) {
+	t.Parallel()
+	err := file.NewReaderError(errors.ErrUnsupported).FixedWidth([]int{4}, func([]string) error {
+		return nil
+	})
+	require.ErrorIs(t, err, errors.ErrUnsupported)
+}
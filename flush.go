@@ -0,0 +1,99 @@
+package file
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// NewBufferedWriter returns a File like NewWriter that collects writes in a
+// buffer of bufSize bytes, so many small writes result in few writes to the
+// file. Use Flush to write the buffered data without closing the file, Close
+// flushes it before closing.
+func NewBufferedWriter(filePath string, bufSize int, opts ...Option) *File {
+	cfg := newConfig(opts)
+	cfg.bufSize = bufSize
+	return cfg.newFile(filePath)
+}
+
+// bufferedWriter buffers writes to dst and flushes them before closing dst.
+type bufferedWriter struct {
+	*bufio.Writer
+	dst io.WriteCloser
+}
+
+func (b *bufferedWriter) Close() error {
+	if err := b.Flush(); err != nil {
+		return errors.Join(fmt.Errorf("failed to flush buffer: %w", err), b.dst.Close())
+	}
+	return b.dst.Close()
+}
+
+func (b *bufferedWriter) Unwrap() io.Writer {
+	return b.dst
+}
+
+// Flush writes buffered data of the writer through to the file. Every layer of
+// the writer that implements Flush() error like bufio.Writer does is flushed
+// from the outside in, e.g. the buffer below WithRecordPadding. For other
+// writers it is a no-op.
+func (f *File) Flush() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Writer == nil {
+		return nil
+	}
+	return flushWriter(f.Writer.Writer)
+}
+
+// Sync commits the written data to stable storage if the writer implements
+// Sync() error like os.File does. Buffered and compressed data is flushed to the
+// file first. Writers that can't be synced, e.g. a bytes.Buffer passed to
+// NewWriterBuffer, fail with errors.ErrUnsupported.
+func (f *File) Sync() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Writer == nil {
+		return nil
+	}
+	return syncWriter(f.Writer.Writer)
+}
+
+// flushWriter flushes the layers of w from the outside in. The wrappers of the
+// writer features expose the writer below them with Unwrap.
+func flushWriter(w io.Writer) error {
+	for {
+		if flusher, ok := w.(interface{ Flush() error }); ok {
+			if err := flusher.Flush(); err != nil {
+				return err
+			}
+		}
+		u, ok := w.(interface{ Unwrap() io.Writer })
+		if !ok {
+			return nil
+		}
+		w = u.Unwrap()
+	}
+}
+
+// syncWriter flushes the layers of w from the outside in and syncs the writer at
+// the bottom. The wrappers of the writer features expose the writer below them
+// with Unwrap.
+func syncWriter(w io.Writer) error {
+	for {
+		if flusher, ok := w.(interface{ Flush() error }); ok {
+			if err := flusher.Flush(); err != nil {
+				return fmt.Errorf("failed to flush writer of type %T: %w", w, err)
+			}
+		}
+		switch v := w.(type) {
+		case interface{ Sync() error }:
+			return v.Sync()
+		case interface{ Unwrap() io.Writer }:
+			w = v.Unwrap()
+		default:
+			return fmt.Errorf("failed to sync writer of type %T: %w", w, errors.ErrUnsupported)
+		}
+	}
+}
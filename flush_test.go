@@ -0,0 +1,175 @@
+package file_test
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlush(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	f := file.NewWriterBuffer(bufio.NewWriter(&buf), "output.log")
+
+	_, err := f.Write([]byte("Hello, World!"))
+	require.NoError(t, err)
+	assert.Empty(t, buf.String())
+
+	require.NoError(t, f.Flush())
+	assert.Equal(t, "Hello, World!", buf.String())
+}
+
+func TestFlushWrapped(t *testing.T) {
+	t.Parallel()
+	filePath := filepath.Join(t.TempDir(), "records.dat")
+	f := file.NewBufferedWriter(filePath, 1024, file.WithRecordPadding(8, ' '))
+
+	// The buffer below the padding is flushed too
+	_, err := f.Write([]byte("Hello"))
+	require.NoError(t, err)
+	require.NoError(t, f.Flush())
+	cnt, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello   ", string(cnt))
+	require.NoError(t, f.Close())
+}
+
+func TestFlushNoop(t *testing.T) {
+	t.Parallel()
+	f := file.NewWriter(filepath.Join(t.TempDir(), "output.log"))
+	require.NoError(t, f.Flush())
+
+	_, err := f.Write([]byte("Hello, World!"))
+	require.NoError(t, err)
+	require.NoError(t, f.Flush())
+	require.NoError(t, f.Close())
+}
+
+// @markdown
+// TestNewBufferedWriter illustrates how to write many small lines efficiently.
+func TestNewBufferedWriter(t *testing.T) {
+	t.Parallel()
+	filePath := filepath.Join(t.TempDir(), "app.log")
+
+	f := file.NewBufferedWriter(filePath, 64*1024)
+	for i := range 100_000 {
+		_, err := fmt.Fprintf(f, "line %d\n", i)
+		require.NoError(t, err)
+	}
+	require.NoError(t, f.Flush())
+
+	lines, err := f.ReadLines()
+	require.NoError(t, err)
+	assert.Len(t, lines, 100_000)
+	assert.Equal(t, "line 99999", lines[len(lines)-1])
+	require.NoError(t, f.Close())
+}
+
+func TestNewBufferedWriterClose(t *testing.T) {
+	t.Parallel()
+	filePath := filepath.Join(t.TempDir(), "app.log")
+
+	f := file.NewBufferedWriter(filePath, 1024)
+	_, err := f.Write([]byte("Hello, World!"))
+	require.NoError(t, err)
+
+	// The write is still in the buffer
+	cnt, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Empty(t, cnt)
+
+	require.NoError(t, f.Close())
+	cnt, err = os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, World!", string(cnt))
+}
+
+func TestNewBufferedWriterConcurrentFlush(t *testing.T) {
+	t.Parallel()
+	filePath := filepath.Join(t.TempDir(), "output.log")
+	f := file.NewBufferedWriter(filePath, 64)
+
+	// Writers and a checkpoint flushing the buffer share the writer
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Go(func() {
+			for range 100 {
+				_, err := f.WriteLine("event")
+				assert.NoError(t, err)
+			}
+		})
+	}
+	wg.Go(func() {
+		for range 100 {
+			assert.NoError(t, f.Flush())
+			assert.NoError(t, f.Sync())
+		}
+	})
+	wg.Wait()
+	require.NoError(t, f.Close())
+
+	cnt, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, bytes.Repeat([]byte("event\n"), 400), cnt)
+}
+
+func TestSync(t *testing.T) {
+	t.Parallel()
+	f := file.NewWriter(filepath.Join(t.TempDir(), "output.log"))
+	_, err := f.Write([]byte("Hello, World!"))
+	require.NoError(t, err)
+
+	require.NoError(t, f.Sync())
+	require.NoError(t, f.Close())
+}
+
+func TestSyncWrappers(t *testing.T) {
+	t.Parallel()
+	for name, newWriter := range map[string]func(string) *file.File{
+		"buffered": func(p string) *file.File { return file.NewBufferedWriter(p, 1024) },
+		"limited":  func(p string) *file.File { return file.NewLimitedWriter(p, 1024) },
+		"hash":     func(p string) *file.File { return file.NewHashWriter(p, sha256.New()) },
+		"gzip":     func(p string) *file.File { return file.NewGzipWriter(p) },
+		"zstd":     func(p string) *file.File { return file.NewZstdWriter(p) },
+		"multi":    func(p string) *file.File { return file.NewMultiWriter(p, p+".copy") },
+		"tail":     func(p string) *file.File { return file.NewWriter(p, file.WithTailBuffer(4)) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			filePath := filepath.Join(t.TempDir(), "output.log")
+			f := newWriter(filePath)
+			_, err := f.Write([]byte("Hello, World!"))
+			require.NoError(t, err)
+
+			// Sync flushes buffered and compressed data before syncing the file
+			require.NoError(t, f.Sync())
+			info, err := os.Stat(filePath)
+			require.NoError(t, err)
+			assert.Positive(t, info.Size())
+			require.NoError(t, f.Close())
+		})
+	}
+}
+
+func TestSyncUnsupported(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	f := file.NewWriterBuffer(&buf, "output.log")
+	_, err := f.Write([]byte("Hello, World!"))
+	require.NoError(t, err)
+
+	err = f.Sync()
+	require.ErrorIs(t, err, errors.ErrUnsupported)
+	assert.ErrorContains(t, err, "*bytes.Buffer")
+}
@@ -0,0 +1,52 @@
+package file
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+)
+
+// FS is an fs.FS of the files below a root directory, e.g. for fs.WalkDir or
+// template.ParseFS. Like os.DirFS it rejects names that are not valid according
+// to fs.ValidPath, such as names containing "..", but follows symbolic links.
+type FS struct {
+	root string
+}
+
+// NewFS returns an FS for the files below root.
+func NewFS(root string) *FS {
+	return &FS{root: root}
+}
+
+// Open implements the fs.FS interface by opening a File for name.
+func (fsys *FS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	f := New(filepath.Join(fsys.root, filepath.FromSlash(name)))
+	reader, err := f.lazyReader()
+	if err != nil {
+		return nil, err
+	}
+	return &fsFile{File: f, reader: reader}, nil
+}
+
+// fsFile adapts a File to fs.File and, for directories, fs.ReadDirFile.
+type fsFile struct {
+	*File
+	reader io.Reader
+}
+
+func (f *fsFile) Read(p []byte) (int, error) {
+	return f.reader.Read(p)
+}
+
+func (f *fsFile) ReadDir(n int) ([]fs.DirEntry, error) {
+	dir, ok := f.reader.(fs.ReadDirFile)
+	if !ok {
+		return nil, fmt.Errorf("failed to read directory %q: %w", f.FilePath, errors.ErrUnsupported)
+	}
+	return dir.ReadDir(n)
+}
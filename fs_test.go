@@ -0,0 +1,50 @@
+package file_test
+
+import (
+	"io/fs"
+	"path/filepath"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// @markdown
+// TestNewFS illustrates how to use a directory with the io/fs functions of the standard library.
+func TestNewFS(t *testing.T) {
+	t.Parallel()
+	fsys := file.NewFS(createTree(t))
+
+	cnt, err := fs.ReadFile(fsys, "bin/run.sh")
+	require.NoError(t, err)
+	assert.Equal(t, "#!/bin/sh", string(cnt))
+
+	info, err := fs.Stat(fsys, "README.md")
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), info.Size())
+
+	var paths []string
+	err = fs.WalkDir(fsys, ".", func(path string, _ fs.DirEntry, err error) error {
+		paths = append(paths, path)
+		return err
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{".", "README.md", "bin", "bin/run.sh", "tmp", "tmp/cache"}, paths)
+}
+
+func TestNewFSPathTraversal(t *testing.T) {
+	t.Parallel()
+	dir := createTree(t)
+	fsys := file.NewFS(filepath.Join(dir, "bin"))
+
+	_, err := fs.ReadFile(fsys, "../README.md")
+	require.ErrorIs(t, err, fs.ErrInvalid)
+
+	_, err = fs.ReadFile(fsys, "/etc/passwd")
+	require.ErrorIs(t, err, fs.ErrInvalid)
+
+	_, err = fs.ReadFile(fsys, "missing.sh")
+	require.ErrorIs(t, err, fs.ErrNotExist)
+}
@@ -0,0 +1,114 @@
+package file
+
+import (
+	"iter"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// OpenGlob returns a File for every path matching pattern, see filepath.Glob for
+// the pattern syntax.
+func OpenGlob(pattern string) ([]*File, error) {
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]*File, 0, len(matches))
+	for _, match := range matches {
+		files = append(files, New(match))
+	}
+	return files, nil
+}
+
+// GlobSeq yields a File for every path matching pattern like OpenGlob, but reads
+// the directories in batches while iterating instead of collecting all matches,
+// so memory stays flat for huge numbers of matches. Matches are yielded in
+// directory order. Each File is independent and has to be closed by the caller.
+// Errors are yielded with a nil File and end the sequence.
+func GlobSeq(pattern string) iter.Seq2[*File, error] {
+	return func(yield func(*File, error) bool) {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			yield(nil, err)
+			return
+		}
+		globSeq(pattern, func(path string, err error) bool {
+			if err != nil {
+				return yield(nil, err)
+			}
+			return yield(New(path), nil)
+		})
+	}
+}
+
+// globSeq calls yield for every path matching pattern and returns false once
+// yield did.
+func globSeq(pattern string, yield func(string, error) bool) bool {
+	if !hasGlobMeta(pattern) {
+		if _, err := os.Lstat(pattern); err != nil {
+			return true
+		}
+		return yield(pattern, nil)
+	}
+	dir, name := filepath.Split(pattern)
+	dir = cleanGlobDir(dir)
+	if !hasGlobMeta(dir) {
+		return globDir(dir, name, yield)
+	}
+	return globSeq(dir, func(match string, err error) bool {
+		if err != nil {
+			return yield("", err)
+		}
+		return globDir(match, name, yield)
+	})
+}
+
+// globDir calls yield for every entry of dir matching the name pattern.
+// Directories that cannot be read are skipped like filepath.Glob does.
+func globDir(dir, name string, yield func(string, error) bool) bool {
+	fh, err := os.Open(dir)
+	if err != nil {
+		return true
+	}
+	defer fh.Close()
+	for {
+		names, err := fh.Readdirnames(readDirBatch)
+		for _, n := range names {
+			matched, err := filepath.Match(name, n)
+			if err != nil {
+				return yield("", err)
+			}
+			if matched && !yield(filepath.Join(dir, n), nil) {
+				return false
+			}
+		}
+		if err != nil {
+			// io.EOF ends the directory, other read errors are skipped.
+			return true
+		}
+	}
+}
+
+// cleanGlobDir strips the trailing separator of a directory returned by
+// filepath.Split.
+func cleanGlobDir(dir string) string {
+	vol := filepath.VolumeName(dir)
+	switch dir {
+	case "":
+		return "."
+	case vol + string(filepath.Separator):
+		return dir
+	}
+	return dir[:len(dir)-1]
+}
+
+// hasGlobMeta reports whether path contains any of the magic characters
+// recognized by filepath.Match.
+func hasGlobMeta(path string) bool {
+	magic := `*?[`
+	if runtime.GOOS != "windows" {
+		magic = `*?[\`
+	}
+	return strings.ContainsAny(path, magic)
+}
@@ -0,0 +1,114 @@
+package file
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// OpenGlobRecursive returns a File for every path matching pattern like OpenGlob,
+// but additionally supports "**" as a complete path element matching zero or more
+// directories, e.g. "src/**/*.go". The tree below the longest leading part of the
+// pattern without wildcards is walked once and directories that cannot contain a
+// match are skipped. The Files are sorted by path.
+func OpenGlobRecursive(pattern string) ([]*File, error) {
+	segs := strings.Split(filepath.ToSlash(pattern), "/")
+	first := len(segs)
+	for i, seg := range segs {
+		if hasGlobMeta(seg) {
+			first = i
+			break
+		}
+	}
+	if first == len(segs) {
+		// Like filepath.Glob a missing path is no match.
+		if _, err := os.Lstat(pattern); err == nil {
+			return []*File{New(pattern)}, nil
+		}
+		return nil, nil
+	}
+	for _, seg := range segs[first:] {
+		if seg == "**" {
+			continue
+		}
+		if _, err := filepath.Match(seg, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	root := filepath.FromSlash(strings.Join(segs[:first], "/"))
+	switch {
+	case root == "" && first > 0:
+		root = string(filepath.Separator)
+	case root == "":
+		root = "."
+	}
+	pat := segs[first:]
+	var files []*File
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == root && errors.Is(err, fs.ErrNotExist) {
+				return filepath.SkipAll
+			}
+			return err
+		}
+		if path == root {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		name := strings.Split(filepath.ToSlash(rel), "/")
+		if matchGlobSegments(pat, name) {
+			files = append(files, New(path))
+		}
+		if d.IsDir() && !matchGlobPrefix(pat, name) {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// matchGlobSegments reports whether the path elements name match the pattern
+// elements pat, where "**" matches any number of elements.
+func matchGlobSegments(pat, name []string) bool {
+	if len(pat) == 0 {
+		return len(name) == 0
+	}
+	if pat[0] == "**" {
+		for i := range len(name) + 1 {
+			if matchGlobSegments(pat[1:], name[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(name) == 0 {
+		return false
+	}
+	matched, err := filepath.Match(pat[0], name[0])
+	return err == nil && matched && matchGlobSegments(pat[1:], name[1:])
+}
+
+// matchGlobPrefix reports whether paths below the directory with the path
+// elements name can match the pattern elements pat.
+func matchGlobPrefix(pat, name []string) bool {
+	if len(name) == 0 {
+		return true
+	}
+	if len(pat) == 0 {
+		return false
+	}
+	if pat[0] == "**" {
+		return true
+	}
+	matched, err := filepath.Match(pat[0], name[0])
+	return err == nil && matched && matchGlobPrefix(pat[1:], name[1:])
+}
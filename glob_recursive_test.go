@@ -0,0 +1,65 @@
+package file_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func paths(files []*file.File) []string {
+	paths := make([]string, 0, len(files))
+	for _, f := range files {
+		paths = append(paths, f.FilePath)
+	}
+	return paths
+}
+
+// @markdown
+// TestOpenGlobRecursive illustrates how to find files in a whole tree with a ** pattern.
+func TestOpenGlobRecursive(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	for _, name := range []string{"main.go", "go.mod", "cmd/app/app.go", "cmd/app/app_test.go", "internal/util.go", "docs/index.md"} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(name), 0o600))
+	}
+
+	files, err := file.OpenGlobRecursive(filepath.Join(dir, "**", "*.go"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "cmd", "app", "app.go"),
+		filepath.Join(dir, "cmd", "app", "app_test.go"),
+		filepath.Join(dir, "internal", "util.go"),
+		filepath.Join(dir, "main.go"),
+	}, paths(files))
+
+	files, err = file.OpenGlobRecursive(filepath.Join(dir, "cmd", "**", "*_test.go"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "cmd", "app", "app_test.go")}, paths(files))
+
+	files, err = file.OpenGlobRecursive(filepath.Join(dir, "*", "*.md"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "docs", "index.md")}, paths(files))
+}
+
+func TestOpenGlobRecursiveNoMatch(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+
+	files, err := file.OpenGlobRecursive(filepath.Join(dir, "missing", "**", "*.go"))
+	require.NoError(t, err)
+	assert.Empty(t, files)
+
+	files, err = file.OpenGlobRecursive(filepath.Join(dir, "missing.go"))
+	require.NoError(t, err)
+	assert.Empty(t, files)
+
+	_, err = file.OpenGlobRecursive(filepath.Join(dir, "**", "["))
+	require.ErrorIs(t, err, filepath.ErrBadPattern)
+}
@@ -0,0 +1,73 @@
+package file_test
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenGlob(t *testing.T) {
+	t.Parallel()
+	dir := createTree(t)
+
+	files, err := file.OpenGlob(filepath.Join(dir, "*", "*"))
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	assert.Equal(t, filepath.Join(dir, "bin", "run.sh"), files[0].FilePath)
+	assert.Equal(t, filepath.Join(dir, "tmp", "cache"), files[1].FilePath)
+
+	_, err = file.OpenGlob("[")
+	require.ErrorIs(t, err, filepath.ErrBadPattern)
+}
+
+// @markdown
+// TestGlobSeq illustrates how to lazily iterate over the files matching a pattern.
+func TestGlobSeq(t *testing.T) {
+	t.Parallel()
+	dir := createTree(t)
+
+	var paths []string
+	for f, err := range file.GlobSeq(filepath.Join(dir, "*", "*")) {
+		require.NoError(t, err)
+		cnt, err := f.Read()
+		require.NoError(t, err)
+		assert.NotEmpty(t, cnt)
+		require.NoError(t, f.Close())
+		paths = append(paths, f.FilePath)
+	}
+	slices.Sort(paths)
+	expected, err := filepath.Glob(filepath.Join(dir, "*", "*"))
+	require.NoError(t, err)
+	assert.Equal(t, expected, paths)
+}
+
+func TestGlobSeqStop(t *testing.T) {
+	t.Parallel()
+	dir := createFiles(t, 10)
+
+	cnt := 0
+	for _, err := range file.GlobSeq(filepath.Join(dir, "*")) {
+		require.NoError(t, err)
+		cnt++
+		if cnt == 3 {
+			break
+		}
+	}
+	assert.Equal(t, 3, cnt)
+}
+
+func TestGlobSeqError(t *testing.T) {
+	t.Parallel()
+	for f, err := range file.GlobSeq("[") {
+		assert.Nil(t, f)
+		require.ErrorIs(t, err, filepath.ErrBadPattern)
+	}
+	for range file.GlobSeq(filepath.Join(t.TempDir(), "missing", "*")) {
+		t.Fatal("unexpected match")
+	}
+}
@@ -2,7 +2,11 @@ module github.com/fr12k/go-file
 
 go 1.26.0
 
-require github.com/stretchr/testify v1.11.1
+require (
+	github.com/klauspost/compress v1.18.0
+	github.com/stretchr/testify v1.11.1
+	golang.org/x/sys v0.38.0
+)
 
 require (
 	4d63.com/gocheckcompilerdirectives v1.3.0 // indirect
@@ -201,7 +205,6 @@ require (
 	golang.org/x/exp/typeparams v0.0.0-20251023183803-a4bb9ffd2546 // indirect
 	golang.org/x/mod v0.30.0 // indirect
 	golang.org/x/sync v0.18.0 // indirect
-	golang.org/x/sys v0.38.0 // indirect
 	golang.org/x/text v0.31.0 // indirect
 	golang.org/x/tools v0.39.0 // indirect
 	google.golang.org/protobuf v1.36.8 // indirect
@@ -0,0 +1,87 @@
+package file
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// NewCompressedReader returns a File like New that transparently decompresses
+// files with a ".gz" suffix. Other files are read unchanged.
+func NewCompressedReader(filePath string, opts ...Option) *File {
+	cfg := newConfig(opts)
+	cfg.decompress = true
+	return cfg.newFile(filePath)
+}
+
+// NewAutoReader returns a File like New that decompresses gzip content detected
+// by its magic bytes, regardless of the file name. Other content is read
+// unchanged.
+func NewAutoReader(filePath string, opts ...Option) *File {
+	cfg := newConfig(opts)
+	cfg.autoDecompress = true
+	return cfg.newFile(filePath)
+}
+
+// gzipMagic are the first bytes of every gzip stream.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// sniffGzip peeks at the first bytes of r without consuming them and wraps r in
+// a gzip reader if they are the gzip magic bytes.
+func sniffGzip(filePath string, r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(len(gzipMagic))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, errors.Join(fmt.Errorf("failed to read %q: %w", filePath, err), closeIfCloser(r))
+	}
+	if !bytes.Equal(magic, gzipMagic) {
+		return readCloser{Reader: br, Closer: closeFunc(func() error {
+			return closeIfCloser(r)
+		})}, nil
+	}
+	gz, err := gzip.NewReader(br)
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("failed to read gzip header of %q: %w", filePath, err), closeIfCloser(r))
+	}
+	return &gzipReader{Reader: gz, src: r}, nil
+}
+
+// NewGzipWriter returns a File like NewWriter whose writes are gzip compressed
+// on the way to filePath. The compressed stream is only complete once Close
+// returned. Use WithGzipLevel to change the default compression level.
+func NewGzipWriter(filePath string, opts ...Option) *File {
+	cfg := newConfig(append([]Option{WithGzipLevel(gzip.DefaultCompression)}, opts...))
+	cfg.compress = true
+	return cfg.newFile(filePath)
+}
+
+// gzipWriter compresses into dst. Close flushes the gzip trailer before closing
+// dst.
+type gzipWriter struct {
+	*gzip.Writer
+	dst io.WriteCloser
+}
+
+func (g *gzipWriter) Close() error {
+	if err := g.Writer.Close(); err != nil {
+		return errors.Join(fmt.Errorf("failed to close gzip stream: %w", err), g.dst.Close())
+	}
+	return g.dst.Close()
+}
+
+func (g *gzipWriter) Unwrap() io.Writer {
+	return g.dst
+}
+
+// gzipReader decompresses src and closes both on Close.
+type gzipReader struct {
+	*gzip.Reader
+	src io.Reader
+}
+
+func (g *gzipReader) Close() error {
+	return errors.Join(g.Reader.Close(), closeIfCloser(g.src))
+}
@@ -0,0 +1,134 @@
+package file_test
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createGzipFile(t *testing.T, cnt string) string {
+	t.Helper()
+	filePath := filepath.Join(t.TempDir(), "test.txt.gz")
+	fh, err := os.Create(filePath)
+	require.NoError(t, err)
+	gw := gzip.NewWriter(fh)
+	_, err = gw.Write([]byte(cnt))
+	require.NoError(t, err)
+	require.NoError(t, gw.Close())
+	require.NoError(t, fh.Close())
+	return filePath
+}
+
+// @markdown
+// TestNewCompressedReader illustrates how to read a gzip compressed file.
+func TestNewCompressedReader(t *testing.T) {
+	t.Parallel()
+	filePath := createGzipFile(t, "Hello, World!")
+
+	f := file.NewCompressedReader(filePath)
+	cnt, err := f.Read()
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, World!", string(cnt))
+	require.NoError(t, f.Close())
+}
+
+func TestNewCompressedReaderPlainFile(t *testing.T) {
+	t.Parallel()
+	f := file.NewCompressedReader(createFile(t, "Hello, World!"))
+	cnt, err := f.Read()
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, World!", string(cnt))
+	require.NoError(t, f.Close())
+}
+
+func TestNewCompressedReaderError(t *testing.T) {
+	t.Parallel()
+	filePath := filepath.Join(t.TempDir(), "broken.gz")
+	require.NoError(t, os.WriteFile(filePath, []byte("this is not a gzip file"), 0o600))
+
+	_, err := file.NewCompressedReader(filePath).Read()
+	require.ErrorIs(t, err, gzip.ErrHeader)
+
+	_, err = file.NewCompressedReader("nonexistent.gz").Read()
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+// @markdown
+// TestNewGzipWriter illustrates how to write a gzip compressed file.
+func TestNewGzipWriter(t *testing.T) {
+	t.Parallel()
+	filePath := filepath.Join(t.TempDir(), "logs", "app.log.gz")
+
+	f := file.NewGzipWriter(filePath, file.WithGzipLevel(gzip.BestCompression))
+	_, err := f.Write([]byte("Hello, "))
+	require.NoError(t, err)
+	_, err = f.Write([]byte("World!"))
+	require.NoError(t, err)
+	assert.Equal(t, filePath, f.Writer.FilePath)
+	require.NoError(t, f.Close())
+
+	fh, err := os.Open(filePath)
+	require.NoError(t, err)
+	defer fh.Close()
+	gr, err := gzip.NewReader(fh)
+	require.NoError(t, err)
+	cnt, err := io.ReadAll(gr)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, World!", string(cnt))
+
+	// The compressed file reads back with NewCompressedReader
+	f = file.NewCompressedReader(filePath)
+	cnt, err = f.Read()
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, World!", string(cnt))
+	require.NoError(t, f.Close())
+}
+
+func TestNewGzipWriterInvalidLevel(t *testing.T) {
+	t.Parallel()
+	filePath := filepath.Join(t.TempDir(), "app.log.gz")
+
+	f := file.NewGzipWriter(filePath, file.WithGzipLevel(42))
+	_, err := f.Write([]byte("Hello, World!"))
+	require.ErrorContains(t, err, "invalid compression level")
+}
+
+func TestNewAutoReader(t *testing.T) {
+	t.Parallel()
+	// The gzip content is detected without the .gz suffix
+	filePath := filepath.Join(t.TempDir(), "app.log")
+	require.NoError(t, os.Rename(createGzipFile(t, "Hello, World!"), filePath))
+
+	f := file.NewAutoReader(filePath)
+	cnt, err := f.Read()
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, World!", string(cnt))
+	require.NoError(t, f.Close())
+}
+
+func TestNewAutoReaderPlainFile(t *testing.T) {
+	t.Parallel()
+	for _, content := range []string{"Hello, World!", "\x1f", ""} {
+		f := file.NewAutoReader(createFile(t, content))
+		cnt, err := f.Read()
+		require.NoError(t, err)
+		assert.Equal(t, content, string(cnt))
+		require.NoError(t, f.Close())
+	}
+}
+
+func TestNewAutoReaderError(t *testing.T) {
+	t.Parallel()
+	_, err := file.NewAutoReader(createFile(t, "\x1f\x8b broken")).Read()
+	require.Error(t, err)
+
+	_, err = file.NewAutoReader("nonexistent.log").Read()
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,89 @@
+package file
+
+import (
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+)
+
+// hashNames maps digest sizes in bytes to the name of the common algorithm.
+var hashNames = map[int]string{
+	16: "md5",
+	20: "sha1",
+	28: "sha224",
+	32: "sha256",
+	48: "sha384",
+	64: "sha512",
+}
+
+func hashName(h hash.Hash) string {
+	if name, ok := hashNames[h.Size()]; ok {
+		return name
+	}
+	return fmt.Sprintf("hash%d", h.Size()*8)
+}
+
+// Hashes reads the file once and feeds it to all given hashes. The hex encoded
+// digests are keyed by the algorithm name derived from the digest size, e.g.
+// "md5", "sha1" or "sha256". Hashes with the same digest size can't be combined.
+func (f *File) Hashes(hs ...func() hash.Hash) (map[string]string, error) {
+	hashers := make(map[string]hash.Hash, len(hs))
+	writers := make([]io.Writer, 0, len(hs))
+	for _, newHash := range hs {
+		h := newHash()
+		name := hashName(h)
+		if _, ok := hashers[name]; ok {
+			return nil, fmt.Errorf("duplicate hash %q", name)
+		}
+		hashers[name] = h
+		writers = append(writers, h)
+	}
+	reader, err := f.lazyReader()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(io.MultiWriter(writers...), reader); err != nil {
+		return nil, fmt.Errorf("failed to hash file %q: %w", f.FilePath, err)
+	}
+	digests := make(map[string]string, len(hashers))
+	for name, h := range hashers {
+		digests[name] = hex.EncodeToString(h.Sum(nil))
+	}
+	return digests, nil
+}
+
+// ReadWithHash reads the whole file like Read and feeds it to h on the way, so
+// h.Sum(nil) returns the digest of the content without a second pass.
+func (f *File) ReadWithHash(h hash.Hash) ([]byte, error) {
+	reader, err := f.lazyReader()
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(io.TeeReader(reader, h))
+}
+
+// NewHashWriter returns a File like NewWriter that additionally feeds every byte
+// written to the file into h. After Close h.Sum(nil) returns the digest of the
+// file content.
+func NewHashWriter(filePath string, h hash.Hash, opts ...Option) *File {
+	cfg := newConfig(opts)
+	cfg.hash = h
+	return cfg.newFile(filePath)
+}
+
+// hashWriter feeds the bytes written to the file into a hash.
+type hashWriter struct {
+	io.WriteCloser
+	hash hash.Hash
+}
+
+func (h *hashWriter) Write(p []byte) (int, error) {
+	n, err := h.WriteCloser.Write(p)
+	h.hash.Write(p[:n])
+	return n, err
+}
+
+func (h *hashWriter) Unwrap() io.Writer {
+	return h.WriteCloser
+}
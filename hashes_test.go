@@ -0,0 +1,93 @@
+package file_test
+
+import (
+	"crypto/md5"  //nolint:gosec // md5 is only used to verify published checksums
+	"crypto/sha1" //nolint:gosec // sha1 is only used to verify published checksums
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// @markdown
+// TestHashes illustrates how to compute several checksums while reading the file only once.
+func TestHashes(t *testing.T) {
+	t.Parallel()
+	f := file.New(createFile(t, "Hello, World!"))
+
+	digests, err := f.Hashes(md5.New, sha1.New, sha256.New)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"md5":    "65a8e27d8879283831b664bd8b7f0ad4",
+		"sha1":   "0a0a9f2a6772942557ab5355d76af442f8f65e01",
+		"sha256": "dffd6021bb2bd5b0af676290809ec3a53191dd81c7f70a4b28688a362182986f",
+	}, digests)
+}
+
+func TestHashesUnknownSize(t *testing.T) {
+	t.Parallel()
+	f := file.New(createFile(t, "Hello, World!"))
+
+	digests, err := f.Hashes(func() hash.Hash { return crc32.NewIEEE() })
+	require.NoError(t, err)
+	assert.Contains(t, digests, "hash32")
+}
+
+func TestHashesDuplicate(t *testing.T) {
+	t.Parallel()
+	f := file.New(createFile(t, "Hello, World!"))
+
+	_, err := f.Hashes(sha256.New, sha256.New)
+	assert.ErrorContains(t, err, "duplicate hash")
+}
+
+func TestHashesReadError(t *testing.T) {
+	t.Parallel()
+	_, err := file.New("nonexistent.txt").Hashes(sha256.New)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestReadWithHash(t *testing.T) {
+	t.Parallel()
+	f := file.New(createFile(t, "Hello, World!"))
+
+	h := sha256.New()
+	cnt, err := f.ReadWithHash(h)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, World!", string(cnt))
+	assert.Equal(t, "dffd6021bb2bd5b0af676290809ec3a53191dd81c7f70a4b28688a362182986f", hex.EncodeToString(h.Sum(nil)))
+	require.NoError(t, f.Close())
+
+	_, err = file.New("nonexistent.txt").ReadWithHash(sha256.New())
+	assert.True(t, os.IsNotExist(err))
+}
+
+// @markdown
+// TestNewHashWriter illustrates how to get the checksum of the written content.
+func TestNewHashWriter(t *testing.T) {
+	t.Parallel()
+	filePath := filepath.Join(t.TempDir(), "dist", "artifact.bin")
+
+	h := sha256.New()
+	f := file.NewHashWriter(filePath, h)
+	_, err := f.Write([]byte("Hello, "))
+	require.NoError(t, err)
+	_, err = f.Write([]byte("World!"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	expected := sha256.Sum256([]byte("Hello, World!"))
+	assert.Equal(t, expected[:], h.Sum(nil))
+
+	cnt, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, World!", string(cnt))
+}
@@ -0,0 +1,27 @@
+package file
+
+import (
+	"bytes"
+	"errors"
+)
+
+// Head returns the first n lines of the file without the line endings. Reading
+// stops after the n-th line, so only about the size of the first n lines is read
+// even from huge files.
+func (f *File) Head(n int) ([][]byte, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+	var lines [][]byte
+	err := f.scanLines(f.config(), func(line []byte) error {
+		lines = append(lines, bytes.Clone(line))
+		if len(lines) == n {
+			return errStopIteration
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errStopIteration) {
+		return nil, err
+	}
+	return lines, nil
+}
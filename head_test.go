@@ -0,0 +1,45 @@
+package file_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHead(t *testing.T) {
+	t.Parallel()
+	var sb strings.Builder
+	for i := range 10000 {
+		fmt.Fprintf(&sb, "line %d\n", i)
+	}
+	f := file.New(createFile(t, sb.String()))
+
+	lines, err := f.Head(3)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"line 0", "line 1", "line 2"}, toStrings(lines))
+	require.NoError(t, f.Close())
+}
+
+func TestHeadFewerLines(t *testing.T) {
+	t.Parallel()
+	f := file.New(createFile(t, "one\ntwo"))
+
+	lines, err := f.Head(5)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"one", "two"}, toStrings(lines))
+
+	lines, err = f.Head(0)
+	require.NoError(t, err)
+	assert.Empty(t, lines)
+}
+
+func TestHeadError(t *testing.T) {
+	t.Parallel()
+	_, err := file.New("nonexistent.txt").Head(3)
+	require.Error(t, err)
+}
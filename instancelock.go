@@ -0,0 +1,68 @@
+package file
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// NewInstanceLock guards against running more than one instance of a program by
+// exclusively creating a lock file at path that holds the current PID. It fails
+// with ErrLocked while another instance holds the lock. The returned release func
+// removes the lock file. With WithReclaimStale a lock file left behind by a
+// process that is no longer running is taken over.
+func NewInstanceLock(path string, opts ...Option) (release func(), err error) {
+	cfg := newConfig(opts)
+	if path, err = cfg.expandPath(path); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return nil, fmt.Errorf("%w %q: %w", ErrCreateDir, filepath.Dir(path), err)
+	}
+	err = createLockFile(path)
+	if errors.Is(err, ErrLocked) && cfg.reclaimStale && isStaleLock(path) {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to remove stale lock %q: %w", path, err)
+		}
+		err = createLockFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return sync.OnceFunc(func() {
+		_ = os.Remove(path)
+	}), nil
+}
+
+func createLockFile(path string) error {
+	fh, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		if os.IsExist(err) {
+			return fmt.Errorf("lock %q is held by another instance: %w", path, ErrLocked)
+		}
+		return fmt.Errorf("failed to create lock %q: %w", path, err)
+	}
+	_, err = fh.WriteString(strconv.Itoa(os.Getpid()))
+	if err = errors.Join(err, fh.Close()); err != nil {
+		return errors.Join(fmt.Errorf("failed to write lock %q: %w", path, err), os.Remove(path))
+	}
+	return nil
+}
+
+// isStaleLock reports whether the lock file names a process that is not running.
+// Lock files that can't be read or parsed are never considered stale.
+func isStaleLock(path string) bool {
+	cnt, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(cnt)))
+	if err != nil || pid <= 0 {
+		return false
+	}
+	return !processAlive(pid)
+}
@@ -0,0 +1,78 @@
+package file_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// @markdown
+// TestNewInstanceLock illustrates how to make sure only one instance of a program runs at a time.
+func TestNewInstanceLock(t *testing.T) {
+	t.Parallel()
+	lockFile := filepath.Join(t.TempDir(), "daemon.pid")
+
+	release, err := file.NewInstanceLock(lockFile)
+	require.NoError(t, err)
+
+	// The lock file holds the PID of the owner
+	cnt, err := os.ReadFile(lockFile)
+	require.NoError(t, err)
+	assert.Equal(t, strconv.Itoa(os.Getpid()), string(cnt))
+
+	// A second instance can't acquire the lock
+	_, err = file.NewInstanceLock(lockFile)
+	require.ErrorIs(t, err, file.ErrLocked)
+
+	// Releasing removes the lock file so it can be acquired again
+	release()
+	release()
+	_, err = os.Stat(lockFile)
+	assert.True(t, os.IsNotExist(err))
+
+	release, err = file.NewInstanceLock(lockFile)
+	require.NoError(t, err)
+	release()
+}
+
+func TestNewInstanceLockReclaimStale(t *testing.T) {
+	t.Parallel()
+	lockFile := filepath.Join(t.TempDir(), "daemon.pid")
+
+	// Run a process to completion to get the PID of a process that is gone
+	cmd := exec.Command(os.Args[0], "-test.run=^$")
+	require.NoError(t, cmd.Run())
+	err := os.WriteFile(lockFile, []byte(strconv.Itoa(cmd.Process.Pid)), 0o600)
+	require.NoError(t, err)
+
+	_, err = file.NewInstanceLock(lockFile)
+	require.ErrorIs(t, err, file.ErrLocked)
+
+	release, err := file.NewInstanceLock(lockFile, file.WithReclaimStale())
+	require.NoError(t, err)
+	defer release()
+
+	cnt, err := os.ReadFile(lockFile)
+	require.NoError(t, err)
+	assert.Equal(t, strconv.Itoa(os.Getpid()), string(cnt))
+}
+
+func TestNewInstanceLockReclaimLiveOwner(t *testing.T) {
+	t.Parallel()
+	lockFile := filepath.Join(t.TempDir(), "daemon.pid")
+
+	release, err := file.NewInstanceLock(lockFile)
+	require.NoError(t, err)
+	defer release()
+
+	// The owner is still running so the lock is not stale
+	_, err = file.NewInstanceLock(lockFile, file.WithReclaimStale())
+	require.ErrorIs(t, err, file.ErrLocked)
+}
@@ -0,0 +1,27 @@
+package file
+
+import (
+	"errors"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// flockHeld reports whether someone holds an exclusive flock on fh. flock locks
+// are invisible to F_GETLK on Linux, so it briefly takes a shared lock without
+// blocking.
+func flockHeld(fh *os.File) (bool, error) {
+	fd := int(fh.Fd()) //nolint:gosec // file descriptors fit into an int
+	for {
+		err := unix.Flock(fd, unix.LOCK_SH|unix.LOCK_NB)
+		switch {
+		case errors.Is(err, unix.EINTR):
+			continue
+		case errors.Is(err, unix.EWOULDBLOCK):
+			return true, nil
+		case err != nil:
+			return false, err
+		}
+		return false, unix.Flock(fd, unix.LOCK_UN)
+	}
+}
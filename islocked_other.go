@@ -0,0 +1,13 @@
+//go:build !unix
+
+package file
+
+import (
+	"errors"
+	"fmt"
+)
+
+// IsLocked is not supported on platforms without flock.
+func (f *File) IsLocked() (bool, error) {
+	return false, fmt.Errorf("lock detection of %q: %w", f.FilePath, errors.ErrUnsupported)
+}
@@ -0,0 +1,44 @@
+//go:build unix
+
+package file
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/sys/unix"
+)
+
+// IsLocked reports whether another process holds an advisory lock on the file.
+// fcntl locks are queried with F_GETLK without acquiring the lock. flock locks,
+// e.g. of a writer of NewLockedWriter, are visible to F_GETLK on the BSDs and
+// macOS. On Linux they aren't and are probed by taking a shared flock without
+// blocking and releasing it right away, so for that moment a non-blocking
+// exclusive flock of another process may fail. fcntl locks held by the calling
+// process are not reported, and like any close of the file, closing the query's
+// file handle releases them.
+func (f *File) IsLocked() (locked bool, err error) {
+	if f.FilePath == "" {
+		return false, ErrNoPath
+	}
+	fh, err := f.config().open(f.FilePath)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		err = errors.Join(err, fh.Close())
+	}()
+	lk := unix.Flock_t{Type: unix.F_WRLCK, Whence: io.SeekStart}
+	if err := unix.FcntlFlock(fh.Fd(), unix.F_GETLK, &lk); err != nil {
+		return false, fmt.Errorf("failed to query lock of %q: %w", f.FilePath, err)
+	}
+	if lk.Type != unix.F_UNLCK {
+		return true, nil
+	}
+	locked, err = flockHeld(fh)
+	if err != nil {
+		return false, fmt.Errorf("failed to query lock of %q: %w", f.FilePath, err)
+	}
+	return locked, nil
+}
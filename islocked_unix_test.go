@@ -0,0 +1,94 @@
+//go:build unix
+
+package file_test
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+)
+
+func TestIsLocked(t *testing.T) {
+	t.Parallel()
+	for _, kind := range []string{"flock", "fcntl"} {
+		t.Run(kind, func(t *testing.T) {
+			t.Parallel()
+			tmpFile := createFile(t, "Hello, World!")
+			f := file.New(tmpFile)
+
+			locked, err := f.IsLocked()
+			require.NoError(t, err)
+			assert.False(t, locked)
+
+			// Let another process hold a lock on the file until its stdin is closed
+			cmd := exec.Command(os.Args[0], "-test.run=^TestLockHelperProcess$")
+			cmd.Env = append(os.Environ(), "GO_FILE_LOCK_HELPER="+tmpFile, "GO_FILE_LOCK_KIND="+kind)
+			stdin, err := cmd.StdinPipe()
+			require.NoError(t, err)
+			stdout, err := cmd.StdoutPipe()
+			require.NoError(t, err)
+			require.NoError(t, cmd.Start())
+
+			line, err := bufio.NewReader(stdout).ReadString('\n')
+			require.NoError(t, err)
+			require.Equal(t, "locked\n", line)
+
+			locked, err = f.IsLocked()
+			require.NoError(t, err)
+			assert.True(t, locked)
+
+			require.NoError(t, stdin.Close())
+			require.NoError(t, cmd.Wait())
+
+			locked, err = f.IsLocked()
+			require.NoError(t, err)
+			assert.False(t, locked)
+		})
+	}
+}
+
+func TestIsLockedErrors(t *testing.T) {
+	t.Parallel()
+	_, err := file.New("nonexistent.txt").IsLocked()
+	assert.True(t, os.IsNotExist(err))
+
+	_, err = file.NewWriterError(os.ErrClosed).IsLocked()
+	assert.ErrorIs(t, err, file.ErrNoPath)
+}
+
+// TestLockHelperProcess isn't a real test, it holds a lock for TestIsLocked.
+func TestLockHelperProcess(t *testing.T) {
+	t.Parallel()
+	filePath := os.Getenv("GO_FILE_LOCK_HELPER")
+	if filePath == "" {
+		t.Skip("only run as helper process")
+	}
+	if os.Getenv("GO_FILE_LOCK_KIND") == "fcntl" {
+		fh, err := os.OpenFile(filePath, os.O_RDWR, 0)
+		require.NoError(t, err)
+		lk := unix.Flock_t{Type: unix.F_WRLCK, Whence: io.SeekStart}
+		require.NoError(t, unix.FcntlFlock(fh.Fd(), unix.F_SETLKW, &lk))
+		fmt.Println("locked")
+		_, err = io.ReadAll(os.Stdin)
+		require.NoError(t, err)
+		require.NoError(t, fh.Close())
+		return
+	}
+	// The lock of NewLockedWriter is taken with the first write
+	f := file.NewLockedWriter(filePath)
+	_, err := f.Write(nil)
+	require.NoError(t, err)
+	fmt.Println("locked")
+	_, err = io.ReadAll(os.Stdin)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+}
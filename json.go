@@ -0,0 +1,72 @@
+package file
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+)
+
+// ReadJSON decodes the JSON content of the file into a value of type T. The
+// reader is closed afterwards like with Decode.
+func ReadJSON[T any](f *File) (T, error) {
+	var v T
+	err := f.Decode(func(r io.Reader) error {
+		return json.NewDecoder(r).Decode(&v)
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return v, nil
+}
+
+// WriteJSON encodes v as JSON into the file. The writer is closed afterwards
+// like with Encode.
+func WriteJSON[T any](f *File, v T) error {
+	return f.Encode(func(w io.Writer) error {
+		return json.NewEncoder(w).Encode(v)
+	})
+}
+
+// DecodeNDJSON returns an iterator over the values of the newline-delimited JSON
+// content of the file, decoding every non-blank line into a T. A line that fails
+// to decode yields its error and the iteration continues with the next line,
+// unless WithStopOnDecodeError is set. The file is streamed line by line, use
+// WithMaxLineSize for lines longer than bufio.MaxScanTokenSize. Like Lines, open
+// errors are returned right away and read errors are yielded at the end.
+func DecodeNDJSON[T any](f *File, opts ...Option) (iter.Seq2[T, error], error) {
+	cfg := f.options(opts)
+	if _, err := f.lazyReader(); err != nil {
+		return nil, err
+	}
+	return func(yield func(T, error) bool) {
+		var zero T
+		stopped := false
+		lineNo := 0
+		err := f.scanLines(cfg, func(line []byte) error {
+			lineNo++
+			if len(bytes.TrimSpace(line)) == 0 {
+				return nil
+			}
+			var v T
+			if err := json.Unmarshal(line, &v); err != nil {
+				err = fmt.Errorf("failed to decode line %d: %w", lineNo, err)
+				if !yield(zero, err) || cfg.stopOnDecodeError {
+					stopped = true
+					return errStopIteration
+				}
+				return nil
+			}
+			if !yield(v, nil) {
+				stopped = true
+				return errStopIteration
+			}
+			return nil
+		})
+		if err != nil && !stopped {
+			yield(zero, err)
+		}
+	}, nil
+}
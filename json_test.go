@@ -0,0 +1,100 @@
+package file_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// @markdown
+// TestReadWriteJSON illustrates how to store a config struct as JSON.
+func TestReadWriteJSON(t *testing.T) {
+	t.Parallel()
+	filePath := filepath.Join(t.TempDir(), "config", "record.json")
+
+	err := file.WriteJSON(file.NewWriter(filePath), record{Name: "gopher", Count: 42})
+	require.NoError(t, err)
+
+	cnt, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"Name":"gopher","Count":42}`, string(cnt))
+
+	got, err := file.ReadJSON[record](file.New(filePath))
+	require.NoError(t, err)
+	assert.Equal(t, record{Name: "gopher", Count: 42}, got)
+}
+
+func TestReadJSONErrors(t *testing.T) {
+	t.Parallel()
+	filePath := createFile(t, "{not json")
+
+	_, err := file.ReadJSON[record](file.New(filePath))
+	var syntaxErr *json.SyntaxError
+	require.ErrorAs(t, err, &syntaxErr)
+	assert.ErrorContains(t, err, filePath)
+
+	_, err = file.ReadJSON[record](file.New("nonexistent.json"))
+	assert.True(t, os.IsNotExist(err))
+
+	err = file.WriteJSON(file.NewWriter(filepath.Join(t.TempDir(), "out.json")), func() {})
+	var typeErr *json.UnsupportedTypeError
+	require.ErrorAs(t, err, &typeErr)
+}
+
+func TestDecodeNDJSON(t *testing.T) {
+	t.Parallel()
+	content := `{"id":1,"kind":"start"}
+{"id":2,"kind":"run"
+{"id":3,"kind":"stop"}
+
+{"id":4,"kind":"exit"}
+`
+	f := file.New(createFile(t, content))
+
+	events, err := file.DecodeNDJSON[event](f)
+	require.NoError(t, err)
+	var got []event
+	var errs []error
+	for e, err := range events {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		got = append(got, e)
+	}
+	// The malformed line doesn't abort the stream
+	assert.Equal(t, []event{{1, "start"}, {3, "stop"}, {4, "exit"}}, got)
+	require.Len(t, errs, 1)
+	require.ErrorContains(t, errs[0], "failed to decode line 2")
+	var syntaxErr *json.SyntaxError
+	require.ErrorAs(t, errs[0], &syntaxErr)
+	require.NoError(t, f.Close())
+}
+
+func TestDecodeNDJSONWithStopOnDecodeError(t *testing.T) {
+	t.Parallel()
+	f := file.New(createFile(t, "{\"id\":1}\nnot json\n{\"id\":3}\n"))
+
+	events, err := file.DecodeNDJSON[event](f, file.WithStopOnDecodeError())
+	require.NoError(t, err)
+	var ids []int
+	var last error
+	for e, err := range events {
+		if err != nil {
+			last = err
+			continue
+		}
+		ids = append(ids, e.ID)
+	}
+	assert.Equal(t, []int{1}, ids)
+	require.ErrorContains(t, last, "failed to decode line 2")
+
+	_, err = file.DecodeNDJSON[event](file.New("nonexistent.ndjson"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,53 @@
+package file
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// jsonSpace is the whitespace allowed between JSON tokens.
+const jsonSpace = " \t\r\n"
+
+// AppendJSONArrayElement appends v to the JSON array stored in the file. The file
+// is created with a single-element array if it doesn't exist or is empty.
+// Elements are written one per line so the file stays readable. Like Update it
+// holds the lock file next to the file and atomically replaces the file, so
+// concurrent appends don't get lost and readers never see a broken array. Every
+// append therefore reads and rewrites the whole array, which gets slow for large
+// files, and leaves the lock file named after the file with a ".lock" suffix in
+// place.
+func (f *File) AppendJSONArrayElement(v any) error {
+	if f.FilePath == "" {
+		return ErrNoPath
+	}
+	elem, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal element: %w", err)
+	}
+	return f.Update(func(old []byte) ([]byte, error) {
+		return appendJSONArrayElement(old, elem)
+	})
+}
+
+// appendJSONArrayElement returns the JSON array in cnt with elem appended.
+func appendJSONArrayElement(cnt, elem []byte) ([]byte, error) {
+	cnt = bytes.TrimRight(cnt, jsonSpace)
+	if len(cnt) == 0 {
+		return slices.Concat([]byte("[\n"), elem, []byte("\n]\n")), nil
+	}
+	if cnt[len(cnt)-1] != ']' {
+		return nil, errors.New("content does not end with a JSON array")
+	}
+	body := bytes.TrimRight(cnt[:len(cnt)-1], jsonSpace)
+	if len(body) == 0 {
+		return nil, errors.New("content does not contain a JSON array")
+	}
+	sep := []byte(",\n")
+	if body[len(body)-1] == '[' {
+		sep = []byte("\n")
+	}
+	return slices.Concat(body, sep, elem, []byte("\n]\n")), nil
+}
@@ -0,0 +1,117 @@
+package file_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type event struct {
+	ID   int    `json:"id"`
+	Kind string `json:"kind"`
+}
+
+func readEvents(t *testing.T, filePath string) []event {
+	t.Helper()
+	cnt, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	var events []event
+	require.NoError(t, json.Unmarshal(cnt, &events), string(cnt))
+	return events
+}
+
+// @markdown
+// TestAppendJSONArrayElement illustrates how to append to a JSON array file that stays valid JSON.
+func TestAppendJSONArrayElement(t *testing.T) {
+	t.Parallel()
+	filePath := filepath.Join(t.TempDir(), "events.json")
+	f := file.New(filePath)
+
+	require.NoError(t, f.AppendJSONArrayElement(event{ID: 1, Kind: "created"}))
+	assert.Equal(t, []event{{1, "created"}}, readEvents(t, filePath))
+
+	require.NoError(t, f.AppendJSONArrayElement(event{ID: 2, Kind: "updated"}))
+	require.NoError(t, f.AppendJSONArrayElement(event{ID: 3, Kind: "deleted"}))
+	assert.Equal(t, []event{{1, "created"}, {2, "updated"}, {3, "deleted"}}, readEvents(t, filePath))
+
+	cnt, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, "[\n"+
+		`{"id":1,"kind":"created"},`+"\n"+
+		`{"id":2,"kind":"updated"},`+"\n"+
+		`{"id":3,"kind":"deleted"}`+"\n]\n", string(cnt))
+}
+
+func TestAppendJSONArrayElementExistingArray(t *testing.T) {
+	t.Parallel()
+	for name, content := range map[string]string{
+		"empty file":     "",
+		"empty array":    "[ ]",
+		"whitespace":     " \n",
+		"padded array":   "[\n  {\"id\": 1, \"kind\": \"created\"}\n]" + strings.Repeat(" \n", 600),
+		"compact array":  `[{"id":1,"kind":"created"}]`,
+		"trailing lines": "[]\n\n\n",
+	} {
+		filePath := createFile(t, content)
+		require.NoError(t, file.New(filePath).AppendJSONArrayElement(event{ID: 2, Kind: "updated"}), name)
+		events := readEvents(t, filePath)
+		assert.Equal(t, event{ID: 2, Kind: "updated"}, events[len(events)-1], name)
+	}
+}
+
+func TestAppendJSONArrayElementConcurrent(t *testing.T) {
+	t.Parallel()
+	filePath := filepath.Join(t.TempDir(), "events.json")
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Go(func() {
+			assert.NoError(t, file.New(filePath).AppendJSONArrayElement(event{ID: i, Kind: "created"}))
+		})
+	}
+	wg.Wait()
+
+	assert.Len(t, readEvents(t, filePath), 20)
+}
+
+func TestAppendJSONArrayElementInRoot(t *testing.T) {
+	t.Parallel()
+	parent := t.TempDir()
+	dir := filepath.Join(parent, "root")
+	require.NoError(t, os.Mkdir(dir, 0o755))
+	root, err := os.OpenRoot(dir)
+	require.NoError(t, err)
+	defer root.Close()
+
+	require.NoError(t, file.NewInRoot(root, "events.json").AppendJSONArrayElement(event{ID: 1, Kind: "created"}))
+	assert.Equal(t, []event{{1, "created"}}, readEvents(t, filepath.Join(dir, "events.json")))
+
+	err = file.NewInRoot(root, "../events.json").AppendJSONArrayElement(event{ID: 1, Kind: "created"})
+	require.Error(t, err)
+	entries, err := os.ReadDir(parent)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestAppendJSONArrayElementErrors(t *testing.T) {
+	t.Parallel()
+	err := file.New(createFile(t, `{"id":1}`)).AppendJSONArrayElement(event{})
+	assert.ErrorContains(t, err, "does not end with a JSON array")
+
+	err = file.New(createFile(t, `]`)).AppendJSONArrayElement(event{})
+	assert.ErrorContains(t, err, "does not contain a JSON array")
+
+	err = file.New(filepath.Join(t.TempDir(), "events.json")).AppendJSONArrayElement(func() {})
+	assert.ErrorContains(t, err, "failed to marshal element")
+
+	err = file.NewReader(strings.NewReader("[]")).AppendJSONArrayElement(event{})
+	assert.ErrorIs(t, err, file.ErrNoPath)
+}
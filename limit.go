@@ -0,0 +1,66 @@
+package file
+
+import (
+	"fmt"
+	"io"
+	"math"
+)
+
+// NewLimitedWriter returns a File like NewWriter that writes at most maxBytes to
+// filePath. A write that would exceed the quota writes up to the limit and fails
+// with ErrQuotaExceeded. A negative maxBytes is treated as 0.
+func NewLimitedWriter(filePath string, maxBytes int64, opts ...Option) *File {
+	cfg := newConfig(opts)
+	cfg.quota, cfg.limited = max(maxBytes, 0), true
+	return cfg.newFile(filePath)
+}
+
+// limitedWriter is the write counterpart of io.LimitedReader.
+type limitedWriter struct {
+	io.WriteCloser
+	remaining int64
+}
+
+func (l *limitedWriter) Write(p []byte) (int, error) {
+	if int64(len(p)) <= l.remaining {
+		n, err := l.WriteCloser.Write(p)
+		l.remaining -= int64(n)
+		return n, err
+	}
+	n, err := l.WriteCloser.Write(p[:l.remaining])
+	l.remaining -= int64(n)
+	if err != nil {
+		return n, err
+	}
+	return n, fmt.Errorf("%d of %d bytes written: %w", n, len(p), ErrQuotaExceeded)
+}
+
+func (l *limitedWriter) Unwrap() io.Writer {
+	return l.WriteCloser
+}
+
+// ReadN reads at most maxBytes of the file, e.g. to bound the memory used for
+// untrusted input. truncated reports whether the file holds more than maxBytes.
+// To find out it reads one byte beyond the limit from the reader. A negative
+// maxBytes is rejected.
+func (f *File) ReadN(maxBytes int64) (cnt []byte, truncated bool, err error) {
+	if maxBytes < 0 {
+		return nil, false, fmt.Errorf("invalid limit %d", maxBytes)
+	}
+	reader, err := f.lazyReader()
+	if err != nil {
+		return nil, false, err
+	}
+	limit := maxBytes
+	if limit < math.MaxInt64 {
+		limit++
+	}
+	cnt, err = io.ReadAll(io.LimitReader(reader, limit))
+	if err != nil {
+		return nil, false, err
+	}
+	if int64(len(cnt)) > maxBytes {
+		return cnt[:maxBytes], true, nil
+	}
+	return cnt, false, nil
+}
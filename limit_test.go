@@ -0,0 +1,104 @@
+package file_test
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLimitedWriter(t *testing.T) {
+	t.Parallel()
+	for name, tc := range map[string]struct {
+		writes   []string
+		n        int
+		err      error
+		expected string
+	}{
+		"under the limit":   {writes: []string{"Hello"}, n: 5, expected: "Hello"},
+		"exactly the limit": {writes: []string{"Hello", ", Wor"}, n: 5, expected: "Hello, Wor"},
+		"over the limit":    {writes: []string{"Hello", ", World!"}, n: 5, err: file.ErrQuotaExceeded, expected: "Hello, Wor"},
+		"after the limit":   {writes: []string{"Hello, Wor", "ld!"}, n: 0, err: file.ErrQuotaExceeded, expected: "Hello, Wor"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			filePath := filepath.Join(t.TempDir(), "output.log")
+
+			f := file.NewLimitedWriter(filePath, 10)
+			var (
+				n   int
+				err error
+			)
+			for _, w := range tc.writes {
+				n, err = f.Write([]byte(w))
+			}
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.n, n)
+			require.NoError(t, f.Close())
+
+			cnt, err := os.ReadFile(filePath)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, string(cnt))
+		})
+	}
+}
+
+func TestNewLimitedWriterNegative(t *testing.T) {
+	t.Parallel()
+	f := file.NewLimitedWriter(filepath.Join(t.TempDir(), "output.log"), -1)
+
+	n, err := f.Write([]byte("Hello"))
+	require.ErrorIs(t, err, file.ErrQuotaExceeded)
+	assert.Zero(t, n)
+	require.NoError(t, f.Close())
+}
+
+func TestReadN(t *testing.T) {
+	t.Parallel()
+	for name, tc := range map[string]struct {
+		cnt       string
+		expected  string
+		truncated bool
+	}{
+		"shorter than max": {cnt: "Hello", expected: "Hello"},
+		"equal to max":     {cnt: "Hello, Wor", expected: "Hello, Wor"},
+		"longer than max":  {cnt: "Hello, World!", expected: "Hello, Wor", truncated: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			f := file.New(createFile(t, tc.cnt))
+
+			cnt, truncated, err := f.ReadN(10)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, string(cnt))
+			assert.Equal(t, tc.truncated, truncated)
+			require.NoError(t, f.Close())
+		})
+	}
+
+	_, _, err := file.New("nonexistent.txt").ReadN(10)
+	assert.True(t, os.IsNotExist(err))
+
+	_, _, err = file.New(createFile(t, "Hello")).ReadN(-1)
+	require.Error(t, err)
+}
+
+func TestReadNMaxInt(t *testing.T) {
+	t.Parallel()
+	f := file.New(createFile(t, "Hello, World!"))
+
+	cnt, truncated, err := f.ReadN(math.MaxInt64)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, World!", string(cnt))
+	assert.False(t, truncated)
+	require.NoError(t, f.Close())
+}
@@ -0,0 +1,87 @@
+package file
+
+import (
+	"bufio"
+	"bytes"
+	"iter"
+)
+
+// Lines returns an iterator over the lines of the file without the line endings.
+// The reader is opened lazily and open errors are returned right away, read
+// errors are yielded at the end of the sequence. A yielded line is only valid
+// until the next iteration. Use WithStripComments to skip comments and blank
+// lines and WithMaxLineSize for lines longer than bufio.MaxScanTokenSize.
+func (f *File) Lines(opts ...Option) (iter.Seq2[[]byte, error], error) {
+	cfg := f.options(opts)
+	if _, err := f.lazyReader(); err != nil {
+		return nil, err
+	}
+	return func(yield func([]byte, error) bool) {
+		stopped := false
+		err := f.scanLines(cfg, func(line []byte) error {
+			if !yield(line, nil) {
+				stopped = true
+				return errStopIteration
+			}
+			return nil
+		})
+		if err != nil && !stopped {
+			yield(nil, err)
+		}
+	}, nil
+}
+
+// ReadLines returns all lines of the file without the line endings.
+func (f *File) ReadLines(opts ...Option) ([]string, error) {
+	var lines []string
+	err := f.scanLines(f.options(opts), func(line []byte) error {
+		lines = append(lines, string(line))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// scanLines calls fn for every line of the lazily opened reader. The line is only
+// valid until fn returns.
+func (f *File) scanLines(cfg *config, fn func(line []byte) error) error {
+	reader, err := f.lazyReader()
+	if err != nil {
+		return err
+	}
+	scanner := bufio.NewScanner(reader)
+	if cfg.maxLineSize > 0 {
+		scanner.Buffer(make([]byte, 0, min(cfg.maxLineSize, bufio.MaxScanTokenSize)), cfg.maxLineSize)
+	}
+	for scanner.Scan() {
+		line, ok := cfg.filterLine(scanner.Bytes())
+		if !ok {
+			continue
+		}
+		if err := fn(line); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// filterLine applies the comment options to line and reports whether it should
+// be kept.
+func (c *config) filterLine(line []byte) ([]byte, bool) {
+	if c.commentPrefix == "" {
+		return line, true
+	}
+	prefix := []byte(c.commentPrefix)
+	if c.inlineComment {
+		if i := bytes.Index(line, prefix); i >= 0 {
+			line = bytes.TrimRight(line[:i], " \t")
+		}
+	}
+	trimmed := bytes.TrimSpace(line)
+	if len(trimmed) == 0 || bytes.HasPrefix(trimmed, prefix) {
+		return nil, false
+	}
+	return line, true
+}
@@ -0,0 +1,103 @@
+package file_test
+
+import (
+	"bufio"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadLines(t *testing.T) {
+	t.Parallel()
+	f := file.New(createFile(t, "one\ntwo\r\nthree"))
+
+	lines, err := f.ReadLines()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"one", "two", "three"}, lines)
+}
+
+// @markdown
+// TestLines illustrates how to process a file line by line without loading it into memory.
+func TestLines(t *testing.T) {
+	t.Parallel()
+	f := file.New(createFile(t, "one\ntwo\nthree\n"))
+
+	seq, err := f.Lines()
+	require.NoError(t, err)
+	var lines []string
+	for line, err := range seq {
+		require.NoError(t, err)
+		lines = append(lines, string(line))
+	}
+	assert.Equal(t, []string{"one", "two", "three"}, lines)
+	require.NoError(t, f.Close())
+}
+
+func TestLinesWithMaxLineSize(t *testing.T) {
+	t.Parallel()
+	long := strings.Repeat("x", 100*1024)
+	filePath := createFile(t, "short\n"+long+"\n")
+
+	_, err := file.New(filePath).ReadLines()
+	require.ErrorIs(t, err, bufio.ErrTooLong)
+
+	lines, err := file.New(filePath).ReadLines(file.WithMaxLineSize(1024 * 1024))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"short", long}, lines)
+
+	_, err = file.New(filePath).ReadLines(file.WithMaxLineSize(16))
+	require.ErrorIs(t, err, bufio.ErrTooLong)
+}
+
+func TestLinesEarlyTermination(t *testing.T) {
+	t.Parallel()
+	f := file.New(createFile(t, "one\ntwo\nthree\n"))
+
+	seq, err := f.Lines()
+	require.NoError(t, err)
+	var lines []string
+	for line, err := range seq {
+		require.NoError(t, err)
+		lines = append(lines, string(line))
+		if len(lines) == 2 {
+			break
+		}
+	}
+	assert.Equal(t, []string{"one", "two"}, lines)
+}
+
+func TestLinesOpenError(t *testing.T) {
+	t.Parallel()
+	_, err := file.New("nonexistent.txt").Lines()
+	assert.True(t, os.IsNotExist(err))
+
+	_, err = file.New("nonexistent.txt").ReadLines()
+	assert.True(t, os.IsNotExist(err))
+}
+
+// @markdown
+// TestWithStripComments illustrates how to read a list file while skipping comments and blank lines.
+func TestWithStripComments(t *testing.T) {
+	t.Parallel()
+	content := "# allowed hosts\n\nexample.com\n   # indented comment\nexample.org # production\n\t\n"
+
+	lines, err := file.New(createFile(t, content)).ReadLines(file.WithStripComments("#"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"example.com", "example.org # production"}, lines)
+
+	// Options can be given on construction as well
+	f := file.New(createFile(t, content), file.WithStripComments("#"), file.WithInlineComments())
+	seq, err := f.Lines()
+	require.NoError(t, err)
+	lines = nil
+	for line, err := range seq {
+		require.NoError(t, err)
+		lines = append(lines, string(line))
+	}
+	assert.Equal(t, []string{"example.com", "example.org"}, lines)
+}
@@ -0,0 +1,24 @@
+package file
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// lockFile opens or creates the file at path, inside the root if one is
+// configured, and blocks until it holds an exclusive advisory lock on it. The
+// returned unlock func releases the lock and closes the file. The lock file
+// itself is left in place.
+func (c *config) lockFile(path string) (unlock func() error, err error) {
+	fh, err := c.openFile(path, os.O_RDWR|os.O_CREATE, c.filePerm())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file: %w", err)
+	}
+	if err := lockFD(fh); err != nil {
+		return nil, errors.Join(fmt.Errorf("failed to lock %q: %w", path, err), fh.Close())
+	}
+	return func() error {
+		return errors.Join(unlockFD(fh), fh.Close())
+	}, nil
+}
@@ -0,0 +1,16 @@
+//go:build !unix && !windows
+
+package file
+
+import (
+	"errors"
+	"os"
+)
+
+func lockFD(*os.File) error {
+	return errors.ErrUnsupported
+}
+
+func unlockFD(*os.File) error {
+	return errors.ErrUnsupported
+}
@@ -0,0 +1,23 @@
+//go:build unix
+
+package file
+
+import (
+	"errors"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+func lockFD(fh *os.File) error {
+	for {
+		err := unix.Flock(int(fh.Fd()), unix.LOCK_EX) //nolint:gosec // file descriptors fit into an int
+		if !errors.Is(err, unix.EINTR) {
+			return err
+		}
+	}
+}
+
+func unlockFD(fh *os.File) error {
+	return unix.Flock(int(fh.Fd()), unix.LOCK_UN) //nolint:gosec // file descriptors fit into an int
+}
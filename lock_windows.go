@@ -0,0 +1,16 @@
+package file
+
+import (
+	"math"
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+func lockFD(fh *os.File) error {
+	return windows.LockFileEx(windows.Handle(fh.Fd()), windows.LOCKFILE_EXCLUSIVE_LOCK, 0, math.MaxUint32, math.MaxUint32, new(windows.Overlapped))
+}
+
+func unlockFD(fh *os.File) error {
+	return windows.UnlockFileEx(windows.Handle(fh.Fd()), 0, math.MaxUint32, math.MaxUint32, new(windows.Overlapped))
+}
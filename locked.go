@@ -0,0 +1,35 @@
+package file
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// NewLockedWriter returns a File like NewAppendWriter that holds an exclusive
+// advisory lock (flock on Unix, LockFileEx on Windows) on the file from its first
+// write until Close. Writers of other processes using NewLockedWriter block until
+// the lock is released, so their writes don't interleave. The lock is advisory,
+// writers that don't lock the file are not stopped.
+func NewLockedWriter(filePath string, opts ...Option) *File {
+	cfg := newConfig(opts)
+	cfg.appendMode = true
+	cfg.locked = true
+	return cfg.newFile(filePath)
+}
+
+// lockedFile is the writer of NewLockedWriter, Close releases the lock.
+type lockedFile struct {
+	*os.File
+}
+
+func newLockedFile(fh *os.File) (*lockedFile, error) {
+	if err := lockFD(fh); err != nil {
+		return nil, errors.Join(fmt.Errorf("failed to lock %q: %w", fh.Name(), err), fh.Close())
+	}
+	return &lockedFile{File: fh}, nil
+}
+
+func (l *lockedFile) Close() error {
+	return errors.Join(unlockFD(l.File), l.File.Close())
+}
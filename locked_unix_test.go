@@ -0,0 +1,48 @@
+//go:build unix
+
+package file_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLockedWriter(t *testing.T) {
+	t.Parallel()
+	filePath := filepath.Join(t.TempDir(), "app.log")
+
+	var wg sync.WaitGroup
+	for _, c := range []string{"a", "b"} {
+		wg.Go(func() {
+			f := file.NewLockedWriter(filePath)
+			line := strings.Repeat(c, 1024)
+			for range 100 {
+				// Each line is written in pieces, only the lock keeps them together
+				_, err := f.WriteString(line[:512])
+				assert.NoError(t, err)
+				_, err = f.WriteString(line[512:] + "\n")
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, f.Close())
+		})
+	}
+	wg.Wait()
+
+	cnt, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	lines := bytes.Split(bytes.TrimSuffix(cnt, []byte("\n")), []byte("\n"))
+	require.Len(t, lines, 200)
+	for _, line := range lines {
+		require.Len(t, line, 1024)
+		assert.Equal(t, bytes.Repeat(line[:1], 1024), line)
+	}
+}
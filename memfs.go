@@ -0,0 +1,177 @@
+package file
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// FileSystem is the file system a File reads and writes through, see WithFS.
+// Without WithFS the operating system's file system is used.
+type FileSystem interface {
+	// Open opens name for reading.
+	Open(name string) (io.ReadCloser, error)
+	// Create creates or truncates name for writing.
+	Create(name string) (io.WriteCloser, error)
+	// MkdirAll creates the directory path and its parents.
+	MkdirAll(path string, perm os.FileMode) error
+	// Stat returns the FileInfo of name.
+	Stat(name string) (os.FileInfo, error)
+}
+
+// MemFS is an in-memory FileSystem, e.g. to test code using New and NewWriter
+// without touching the disk. Written content is visible to readers opened
+// afterwards. It is safe for concurrent use.
+type MemFS struct {
+	mu    sync.Mutex
+	files map[string]*memEntry
+}
+
+type memEntry struct {
+	data    []byte
+	mode    os.FileMode
+	modTime time.Time
+}
+
+// NewMemFS returns an empty MemFS.
+func NewMemFS() *MemFS {
+	return &MemFS{files: map[string]*memEntry{}}
+}
+
+// Open implements FileSystem by returning a reader of the current content of
+// name, which also implements io.Seeker and io.ReaderAt.
+func (m *MemFS) Open(name string) (io.ReadCloser, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.files[filepath.Clean(name)]
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	if e.mode.IsDir() {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	return memReader{Reader: bytes.NewReader(bytes.Clone(e.data))}, nil
+}
+
+// Create implements FileSystem. The parent directory must exist like on a real
+// file system.
+func (m *MemFS) Create(name string) (io.WriteCloser, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	name = filepath.Clean(name)
+	if dir, ok := m.files[filepath.Dir(name)]; !ok || !dir.mode.IsDir() {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	if e, ok := m.files[name]; ok && e.mode.IsDir() {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrExist}
+	}
+	m.files[name] = &memEntry{mode: 0o666, modTime: time.Now()}
+	return &memWriter{fsys: m, name: name}, nil
+}
+
+// MkdirAll implements FileSystem.
+func (m *MemFS) MkdirAll(path string, perm os.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for dir := filepath.Clean(path); ; dir = filepath.Dir(dir) {
+		if e, ok := m.files[dir]; ok {
+			if !e.mode.IsDir() {
+				return &fs.PathError{Op: "mkdir", Path: dir, Err: fs.ErrExist}
+			}
+		} else {
+			m.files[dir] = &memEntry{mode: fs.ModeDir | perm.Perm(), modTime: time.Now()}
+		}
+		if filepath.Dir(dir) == dir {
+			return nil
+		}
+	}
+}
+
+// Stat implements FileSystem.
+func (m *MemFS) Stat(name string) (os.FileInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	name = filepath.Clean(name)
+	e, ok := m.files[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+	}
+	return memFileInfo{name: filepath.Base(name), size: int64(len(e.data)), mode: e.mode, modTime: e.modTime}, nil
+}
+
+// memReader is a reader of MemFS with a no-op Close.
+type memReader struct {
+	*bytes.Reader
+}
+
+func (memReader) Close() error {
+	return nil
+}
+
+// memWriter appends to a file of MemFS.
+type memWriter struct {
+	fsys   *MemFS
+	name   string
+	closed bool
+}
+
+func (w *memWriter) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, &fs.PathError{Op: "write", Path: w.name, Err: fs.ErrClosed}
+	}
+	w.fsys.mu.Lock()
+	defer w.fsys.mu.Unlock()
+	e, ok := w.fsys.files[w.name]
+	if !ok {
+		// The file was replaced or removed meanwhile, keep writing to a new one
+		e = &memEntry{mode: 0o666}
+		w.fsys.files[w.name] = e
+	}
+	e.data = append(e.data, p...)
+	e.modTime = time.Now()
+	return len(p), nil
+}
+
+func (w *memWriter) Close() error {
+	if w.closed {
+		return &fs.PathError{Op: "close", Path: w.name, Err: fs.ErrClosed}
+	}
+	w.closed = true
+	return nil
+}
+
+// memFileInfo is the os.FileInfo of a MemFS entry.
+type memFileInfo struct {
+	name    string
+	size    int64
+	mode    os.FileMode
+	modTime time.Time
+}
+
+func (i memFileInfo) Name() string {
+	return i.name
+}
+
+func (i memFileInfo) Size() int64 {
+	return i.size
+}
+
+func (i memFileInfo) Mode() os.FileMode {
+	return i.mode
+}
+
+func (i memFileInfo) ModTime() time.Time {
+	return i.modTime
+}
+
+func (i memFileInfo) IsDir() bool {
+	return i.mode.IsDir()
+}
+
+func (i memFileInfo) Sys() any {
+	return nil
+}
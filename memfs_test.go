@@ -0,0 +1,82 @@
+package file_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// @markdown
+// TestWithFS illustrates how to test code using New and NewWriter without touching the disk.
+func TestWithFS(t *testing.T) {
+	t.Parallel()
+	fsys := file.NewMemFS()
+	filePath := filepath.Join(t.TempDir(), "config", "app.json")
+
+	w := file.NewWriter(filePath, file.WithFS(fsys))
+	_, err := w.WriteString(`{"debug":true}`)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	r := file.New(filePath, file.WithFS(fsys))
+	cnt, err := r.Read()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"debug":true}`, string(cnt))
+	require.NoError(t, r.Close())
+
+	size, err := r.Size()
+	require.NoError(t, err)
+	assert.Equal(t, int64(14), size)
+
+	// Nothing was written to the disk
+	_, err = os.Stat(filepath.Dir(filePath))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestWithFSNotExist(t *testing.T) {
+	t.Parallel()
+	fsys := file.NewMemFS()
+
+	f := file.New("missing.txt", file.WithFS(fsys))
+	ok, err := f.Exists()
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, err = f.Read()
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestMemFS(t *testing.T) {
+	t.Parallel()
+	fsys := file.NewMemFS()
+
+	// Like on disk the parent directory must exist
+	_, err := fsys.Create("logs/app.log")
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	require.NoError(t, fsys.MkdirAll("logs", 0o755))
+	w, err := fsys.Create("logs/app.log")
+	require.NoError(t, err)
+	_, err = w.Write([]byte("started\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	_, err = w.Write([]byte("closed\n"))
+	require.ErrorIs(t, err, os.ErrClosed)
+
+	info, err := fsys.Stat("logs")
+	require.NoError(t, err)
+	assert.True(t, info.IsDir())
+
+	info, err = fsys.Stat("logs/app.log")
+	require.NoError(t, err)
+	assert.Equal(t, "app.log", info.Name())
+	assert.Equal(t, int64(8), info.Size())
+
+	_, err = fsys.Open("logs")
+	require.ErrorIs(t, err, os.ErrInvalid)
+}
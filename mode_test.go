@@ -0,0 +1,108 @@
+package file_test
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// @markdown
+// TestWithFileMode illustrates how to create a private file in a private directory.
+func TestWithFileMode(t *testing.T) {
+	t.Parallel()
+	dir := filepath.Join(t.TempDir(), "secrets")
+	testFilePath := filepath.Join(dir, "token")
+
+	f := file.NewWriter(testFilePath, file.WithFileMode(0o600), file.WithDirMode(0o700))
+	_, err := f.Write([]byte("secret"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	info, err := os.Stat(testFilePath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode())
+
+	info, err = os.Stat(dir)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o700), info.Mode().Perm())
+}
+
+func TestWithFileModeExisting(t *testing.T) {
+	t.Parallel()
+	for name, newWriter := range map[string]func(string, ...file.Option) *file.File{
+		"writer": file.NewWriter,
+		"append": file.NewAppendWriter,
+		"atomic": file.NewAtomicWriter,
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			testFilePath := createFile(t, "content")
+			require.NoError(t, os.Chmod(testFilePath, 0o644))
+
+			f := newWriter(testFilePath, file.WithFileMode(0o640))
+			_, err := f.Write([]byte("new"))
+			require.NoError(t, err)
+			require.NoError(t, f.Close())
+
+			info, err := os.Stat(testFilePath)
+			require.NoError(t, err)
+			assert.Equal(t, os.FileMode(0o640), info.Mode())
+		})
+	}
+}
+
+func TestWithDirModeAtomic(t *testing.T) {
+	t.Parallel()
+	dir := filepath.Join(t.TempDir(), "secrets")
+
+	f := file.NewAtomicWriter(filepath.Join(dir, "token"), file.WithDirMode(0o700))
+	_, err := f.Write([]byte("secret"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	info, err := os.Stat(dir)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o700), info.Mode().Perm())
+}
+
+func TestAtomicWritersNewFileMode(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	plain := filepath.Join(dir, "plain")
+	require.NoError(t, file.NewWriter(plain).Encode(func(io.Writer) error { return nil }))
+	expected, err := os.Stat(plain)
+	require.NoError(t, err)
+
+	// New files of atomic writers are as accessible as those of NewWriter
+	for name, write := range map[string]func(string) error{
+		"atomic": func(filePath string) error {
+			f := file.NewAtomicWriter(filePath)
+			_, err := f.Write([]byte("new"))
+			return errors.Join(err, f.Close())
+		},
+		"update": func(filePath string) error {
+			return file.New(filePath).Update(func([]byte) ([]byte, error) { return []byte("new"), nil })
+		},
+		"cas": func(filePath string) error {
+			return file.New(filePath).WriteCAS([]byte("new"), time.Time{})
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			filePath := filepath.Join(t.TempDir(), "config")
+			require.NoError(t, write(filePath))
+
+			info, err := os.Stat(filePath)
+			require.NoError(t, err)
+			assert.Equal(t, expected.Mode(), info.Mode())
+		})
+	}
+}
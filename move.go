@@ -0,0 +1,73 @@
+package file
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// Move closes the open reader and writer of the file and moves it to dst,
+// creating the parent directory of dst. If the rename fails because dst is on a
+// different file system, the file is copied and the original removed. Afterwards
+// the File refers to dst.
+func (f *File) Move(dst string) error {
+	return f.move(dst, f.config().rename)
+}
+
+func (f *File) move(dst string, rename func(oldname, newname string) error) error {
+	src := f.path()
+	if src == "" {
+		return ErrNoPath
+	}
+	if err := errors.Join(f.closeReader(), f.closeWriter()); err != nil {
+		return err
+	}
+	cfg := f.config()
+	if err := cfg.mkdirParent(dst); err != nil {
+		return err
+	}
+	err := rename(src, dst)
+	if isCrossDevice(err) {
+		err = cfg.moveByCopy(src, dst)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to move %q to %q: %w", src, dst, err)
+	}
+	f.FilePath = dst
+	f.reader = sync.OnceValues(readerFunc(dst, cfg))
+	f.writer = sync.OnceValue(writerFunc(dst, cfg))
+	return nil
+}
+
+// isCrossDevice reports whether err is the error of a rename across file
+// systems.
+func isCrossDevice(err error) bool {
+	return errors.Is(err, errCrossDevice)
+}
+
+// moveByCopy copies src to dst keeping its permissions and removes src, inside
+// the root if one is configured.
+func (c *config) moveByCopy(src, dst string) error {
+	in, err := c.open(src)
+	if err != nil {
+		return err
+	}
+	info, err := in.Stat()
+	if err != nil {
+		return errors.Join(err, in.Close())
+	}
+	out, err := c.openFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return errors.Join(err, in.Close())
+	}
+	_, err = io.Copy(out, in)
+	if err = errors.Join(err, out.Close(), in.Close()); err != nil {
+		return errors.Join(err, c.remove(dst))
+	}
+	if err := c.chmodPath(dst, info.Mode().Perm()); err != nil {
+		return err
+	}
+	return c.remove(src)
+}
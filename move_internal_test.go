@@ -0,0 +1,59 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoveCrossDevice(t *testing.T) {
+	t.Parallel()
+	src := filepath.Join(t.TempDir(), "input.txt")
+	require.NoError(t, os.WriteFile(src, []byte("Hello, World!"), 0o640))
+	dst := filepath.Join(t.TempDir(), "moved", "input.txt")
+
+	f := New(src)
+	err := f.move(dst, func(oldname, newname string) error {
+		return &os.LinkError{Op: "rename", Old: oldname, New: newname, Err: errCrossDevice}
+	})
+	require.NoError(t, err)
+	assert.Equal(t, dst, f.FilePath)
+
+	_, err = os.Stat(src)
+	require.ErrorIs(t, err, os.ErrNotExist)
+	info, err := os.Stat(dst)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o640), info.Mode().Perm())
+	cnt, err := f.Read()
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, World!", string(cnt))
+	require.NoError(t, f.Close())
+}
+
+func TestMoveCrossDeviceInRoot(t *testing.T) {
+	t.Parallel()
+	parent := t.TempDir()
+	dir := filepath.Join(parent, "root")
+	require.NoError(t, os.Mkdir(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "input.txt"), []byte("Hello, World!"), 0o640))
+	root, err := os.OpenRoot(dir)
+	require.NoError(t, err)
+	defer root.Close()
+
+	f := NewInRoot(root, "input.txt")
+	err = f.move("moved/input.txt", func(oldname, newname string) error {
+		return &os.LinkError{Op: "rename", Old: oldname, New: newname, Err: errCrossDevice}
+	})
+	require.NoError(t, err)
+	cnt, err := os.ReadFile(filepath.Join(dir, "moved", "input.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, World!", string(cnt))
+
+	err = f.config().moveByCopy("moved/input.txt", "../escaped.txt")
+	require.Error(t, err)
+	assert.NoFileExists(t, filepath.Join(parent, "escaped.txt"))
+	assert.FileExists(t, filepath.Join(dir, "moved", "input.txt"))
+}
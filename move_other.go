@@ -0,0 +1,8 @@
+//go:build !plan9 && !windows
+
+package file
+
+import "syscall"
+
+// errCrossDevice is the error of a rename across file systems.
+var errCrossDevice error = syscall.EXDEV
@@ -0,0 +1,7 @@
+package file
+
+import "errors"
+
+// errCrossDevice is never returned on Plan 9, where renames only change the name
+// of a file within its directory.
+var errCrossDevice = errors.New("cross-device link")
@@ -0,0 +1,58 @@
+package file_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMove(t *testing.T) {
+	t.Parallel()
+	src := createFile(t, "Hello, World!")
+	dst := filepath.Join(filepath.Dir(src), "renamed.txt")
+
+	f := file.New(src)
+	cnt, err := f.Read()
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, World!", string(cnt))
+
+	require.NoError(t, f.Move(dst))
+	assert.Equal(t, dst, f.FilePath)
+	_, err = os.Stat(src)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	// The File reads from the new location
+	cnt, err = f.Read()
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, World!", string(cnt))
+	require.NoError(t, f.Close())
+}
+
+func TestMoveToNewDirectory(t *testing.T) {
+	t.Parallel()
+	dst := filepath.Join(t.TempDir(), "archive", "2024", "output.log")
+
+	f := file.NewWriter(filepath.Join(t.TempDir(), "output.log"))
+	_, err := f.Write([]byte("Hello, World!"))
+	require.NoError(t, err)
+
+	require.NoError(t, f.Move(dst))
+	cnt, err := os.ReadFile(dst)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, World!", string(cnt))
+}
+
+func TestMoveErrors(t *testing.T) {
+	t.Parallel()
+	err := file.New(filepath.Join(t.TempDir(), "nonexistent.txt")).Move(filepath.Join(t.TempDir(), "moved.txt"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	err = file.NewReader(strings.NewReader("Hello, World!")).Move(filepath.Join(t.TempDir(), "moved.txt"))
+	require.ErrorIs(t, err, file.ErrNoPath)
+}
@@ -0,0 +1,6 @@
+package file
+
+import "golang.org/x/sys/windows"
+
+// errCrossDevice is the error of a rename across volumes.
+var errCrossDevice error = windows.ERROR_NOT_SAME_DEVICE
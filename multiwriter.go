@@ -0,0 +1,72 @@
+package file
+
+import (
+	"errors"
+	"io"
+	"sync"
+)
+
+// NewMultiWriter returns a File whose writes go to the files at all paths like
+// io.MultiWriter does, each created like NewWriter would. The FilePath, the
+// Writer fields and the reader refer to the first path. Close closes all files.
+func NewMultiWriter(paths ...string) *File {
+	if len(paths) == 0 {
+		return NewWriterError(ErrNoPath)
+	}
+	cfg := newConfig(nil)
+	writer := func() func() (*Writer, error) {
+		creates := make([]func() (*Writer, error), 0, len(paths))
+		for _, path := range paths {
+			creates = append(creates, writerFunc(path, cfg)())
+		}
+		return func() (*Writer, error) {
+			mw := &multiWriteCloser{}
+			var first *Writer
+			for _, create := range creates {
+				w, err := create()
+				if err != nil {
+					return nil, errors.Join(err, mw.Close())
+				}
+				if first == nil {
+					first = w
+				}
+				mw.writers = append(mw.writers, w.Writer)
+			}
+			return &Writer{Directory: first.Directory, FileName: first.FileName, FilePath: first.FilePath, Writer: mw}, nil
+		}
+	}
+	return &File{
+		FilePath: paths[0],
+		reader:   sync.OnceValues(readerFunc(paths[0], cfg)),
+		writer:   sync.OnceValue(writer),
+		cfg:      cfg,
+	}
+}
+
+// multiWriteCloser writes to all writers and closes all of them.
+type multiWriteCloser struct {
+	writers []io.Writer
+}
+
+func (m *multiWriteCloser) Write(p []byte) (int, error) {
+	return io.MultiWriter(m.writers...).Write(p)
+}
+
+// Sync syncs all writers and fails if any of them can't be synced.
+func (m *multiWriteCloser) Sync() error {
+	errs := make([]error, 0, len(m.writers))
+	for _, w := range m.writers {
+		errs = append(errs, syncWriter(w))
+	}
+	return errors.Join(errs...)
+}
+
+func (m *multiWriteCloser) Close() error {
+	errs := make([]error, 0, len(m.writers))
+	for _, w := range m.writers {
+		if closer, ok := w.(io.Closer); ok {
+			errs = append(errs, closer.Close())
+		}
+	}
+	return errors.Join(errs...)
+}
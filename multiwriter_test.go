@@ -0,0 +1,49 @@
+package file_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// @markdown
+// TestNewMultiWriter illustrates how to write the same content to a primary and a backup file.
+func TestNewMultiWriter(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	primary := filepath.Join(dir, "primary", "output.log")
+	backup := filepath.Join(dir, "backup", "output.log")
+
+	f := file.NewMultiWriter(primary, backup)
+	_, err := f.Write([]byte("Hello, "))
+	require.NoError(t, err)
+	_, err = f.Write([]byte("World!"))
+	require.NoError(t, err)
+	assert.Equal(t, primary, f.Writer.FilePath)
+	require.NoError(t, f.Close())
+
+	for _, path := range []string{primary, backup} {
+		cnt, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "Hello, World!", string(cnt))
+	}
+}
+
+func TestNewMultiWriterError(t *testing.T) {
+	t.Parallel()
+	primary := filepath.Join(t.TempDir(), "output.log")
+	// A regular file can't be used as parent directory
+	backup := filepath.Join(createFile(t, "not a directory"), "output.log")
+
+	f := file.NewMultiWriter(primary, backup)
+	_, err := f.Write([]byte("Hello, World!"))
+	require.ErrorContains(t, err, "failed to create directory")
+
+	_, err = file.NewMultiWriter().Write([]byte("Hello, World!"))
+	require.ErrorIs(t, err, file.ErrNoPath)
+}
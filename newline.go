@@ -0,0 +1,47 @@
+package file
+
+import (
+	"io"
+)
+
+// NewNormalizedReader returns a File like New whose reader converts CRLF and
+// lone CR line endings to LF while reading.
+func NewNormalizedReader(filePath string, opts ...Option) *File {
+	cfg := newConfig(opts)
+	cfg.normalizeNewlines = true
+	return cfg.newFile(filePath)
+}
+
+// newlineReader converts CRLF and CR to LF. A CR is emitted as LF right away and
+// the LF following it is dropped, even if it only arrives with the next read.
+type newlineReader struct {
+	src       io.Reader
+	pendingCR bool
+}
+
+func (n *newlineReader) Read(p []byte) (int, error) {
+	for {
+		m, err := n.src.Read(p)
+		out := 0
+		for _, b := range p[:m] {
+			if b == '\n' && n.pendingCR {
+				n.pendingCR = false
+				continue
+			}
+			n.pendingCR = b == '\r'
+			if n.pendingCR {
+				b = '\n'
+			}
+			p[out] = b
+			out++
+		}
+		// Don't report an empty read when only a dropped LF was read.
+		if out > 0 || err != nil || m == 0 {
+			return out, err
+		}
+	}
+}
+
+func (n *newlineReader) Close() error {
+	return closeIfCloser(n.src)
+}
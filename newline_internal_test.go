@@ -0,0 +1,32 @@
+package file
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewlineReaderBoundary(t *testing.T) {
+	t.Parallel()
+	// Every read returns a single byte, so each CRLF is split across two reads
+	r := &newlineReader{src: iotest.OneByteReader(strings.NewReader("a\r\nb\r\r\nc\n"))}
+
+	p := make([]byte, 8)
+	var got []byte
+	for {
+		n, err := r.Read(p)
+		got = append(got, p[:n]...)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		// A read that only dropped the LF of a CRLF must not look like an empty read
+		assert.Positive(t, n)
+	}
+	assert.Equal(t, "a\nb\n\nc\n", string(got))
+}
@@ -0,0 +1,20 @@
+package file_test
+
+import (
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewNormalizedReader(t *testing.T) {
+	t.Parallel()
+	f := file.NewNormalizedReader(createFile(t, "one\r\ntwo\nthree\rfour\r\n\r\nfive\r"))
+
+	cnt, err := f.Read()
+	require.NoError(t, err)
+	assert.Equal(t, "one\ntwo\nthree\nfour\n\nfive\n", string(cnt))
+	require.NoError(t, f.Close())
+}
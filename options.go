@@ -0,0 +1,326 @@
+package file
+
+import (
+	"hash"
+	"io/fs"
+	"os"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Option configures optional behavior of a File or one of its methods.
+type Option func(*config)
+
+type config struct {
+	timeout           time.Duration
+	padShortLines     bool
+	reclaimStale      bool
+	siUnits           bool
+	sidecarAlgo       string
+	fdPool            *fdPool
+	filter            func(path string, d fs.DirEntry) bool
+	commentPrefix     string
+	inlineComment     bool
+	root              *os.Root
+	atomic            bool
+	atomicTemp        *atomicFile
+	noFsync           bool
+	appendMode        bool
+	chunkSize         int
+	tailSize          int
+	tail              *ringBuffer
+	retryAttempts     int
+	retryable         func(error) bool
+	recordSize        int
+	recordPad         byte
+	truncateRecords   bool
+	fileMode          os.FileMode
+	dirMode           os.FileMode
+	maxLineSize       int
+	decompress        bool
+	compress          bool
+	gzipLevel         int
+	hash              hash.Hash
+	maxBytes          int64
+	rotating          bool
+	maxBackups        int
+	quota             int64
+	limited           bool
+	bufSize           int
+	randomAccess      bool
+	expand            bool
+	normalizeNewlines bool
+	stripBOM          bool
+	fsys              FileSystem
+	locked            bool
+	zstdDecompress    bool
+	zstdCompress      bool
+	zstdLevel         zstd.EncoderLevel
+	backup            bool
+	backupSuffix      string
+	csvDelimiter      rune
+	csvHeader         bool
+	stopOnDecodeError bool
+	autoDecompress    bool
+}
+
+func newConfig(opts []Option) *config {
+	cfg := &config{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// config returns the options the File was constructed with.
+func (f *File) config() *config {
+	if f.cfg == nil {
+		return &config{}
+	}
+	return f.cfg
+}
+
+// options returns the options the File was constructed with overridden by opts.
+func (f *File) options(opts []Option) *config {
+	cfg := *f.config()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return &cfg
+}
+
+// WithTimeout bounds how long an operation may wait before giving up.
+func WithTimeout(d time.Duration) Option {
+	return func(c *config) {
+		c.timeout = d
+	}
+}
+
+// WithPadShortLines treats missing bytes of short fixed-width records as spaces
+// instead of failing with ErrShortRecord.
+func WithPadShortLines() Option {
+	return func(c *config) {
+		c.padShortLines = true
+	}
+}
+
+// WithReclaimStale lets NewInstanceLock take over a lock file whose owning
+// process is no longer running.
+func WithReclaimStale() Option {
+	return func(c *config) {
+		c.reclaimStale = true
+	}
+}
+
+// WithSIUnits formats sizes in powers of 1000 (kB, MB, ...) instead of the
+// default powers of 1024 (KiB, MiB, ...).
+func WithSIUnits() Option {
+	return func(c *config) {
+		c.siUnits = true
+	}
+}
+
+// WithSidecarChecksum writes a checksum file next to the written file on Close,
+// named after the file with the algorithm as extension, e.g. "app.tar.sha256".
+// Supported algorithms are md5, sha1, sha256 and sha512.
+func WithSidecarChecksum(algo string) Option {
+	return func(c *config) {
+		c.sidecarAlgo = algo
+	}
+}
+
+// WithManagedFD registers the file handle of the reader with the package-level
+// handle pool, see SetMaxOpenFiles.
+func WithManagedFD() Option {
+	return func(c *config) {
+		c.fdPool = defaultFDPool
+	}
+}
+
+// WithFilter limits the entries walked by directory operations like TarGz to
+// those for which fn returns true. The path is relative to the walked directory
+// and uses forward slashes. Excluding a directory skips all of its content.
+func WithFilter(fn func(path string, d fs.DirEntry) bool) Option {
+	return func(c *config) {
+		c.filter = fn
+	}
+}
+
+// WithStripComments makes the line reader skip blank lines and lines starting
+// with prefix after leading whitespace, e.g. "#".
+func WithStripComments(prefix string) Option {
+	return func(c *config) {
+		c.commentPrefix = prefix
+	}
+}
+
+// WithInlineComments additionally removes trailing comments and the whitespace in
+// front of them from lines when used together with WithStripComments.
+func WithInlineComments() Option {
+	return func(c *config) {
+		c.inlineComment = true
+	}
+}
+
+// WithoutFsync skips the fsync calls of the atomic writer. Readers still never see
+// a partially written file, but after a crash or power loss the file may hold the
+// old content or, on some file systems, be empty.
+func WithoutFsync() Option {
+	return func(c *config) {
+		c.noFsync = true
+	}
+}
+
+// WithAtomicChunks splits writes larger than AtomicAppendSize into several
+// writes of at most AtomicAppendSize bytes, so each of them is appended
+// atomically by a shared append writer.
+func WithAtomicChunks() Option {
+	return func(c *config) {
+		c.chunkSize = AtomicAppendSize
+	}
+}
+
+// WithTailBuffer keeps the last size bytes written to the file in memory, see
+// TailBytes.
+func WithTailBuffer(size int) Option {
+	return func(c *config) {
+		c.tailSize = size
+	}
+}
+
+// WithRetry retries opening the reader up to attempts times with a short
+// exponential backoff as long as retryable reports the error as transient, e.g.
+// IsTextFileBusy.
+func WithRetry(attempts int, retryable func(error) bool) Option {
+	return func(c *config) {
+		c.retryAttempts = attempts
+		c.retryable = retryable
+	}
+}
+
+// WithRecordPadding pads every Write to exactly size bytes with the pad byte, so
+// the file consists of uniform records that can be read by index with ReadAt.
+// Writes longer than size fail with ErrRecordTooLong unless WithTruncateRecords
+// is set.
+func WithRecordPadding(size int, pad byte) Option {
+	return func(c *config) {
+		c.recordSize = size
+		c.recordPad = pad
+	}
+}
+
+// WithTruncateRecords cuts writes longer than the record size of
+// WithRecordPadding instead of failing them.
+func WithTruncateRecords() Option {
+	return func(c *config) {
+		c.truncateRecords = true
+	}
+}
+
+// WithFileMode sets the permissions of files created by a writer, e.g. 0o600.
+// The mode is applied exactly regardless of the umask, also to existing files.
+func WithFileMode(mode os.FileMode) Option {
+	return func(c *config) {
+		c.fileMode = mode
+	}
+}
+
+// WithDirMode sets the permissions of the parent directories created by a
+// writer, e.g. 0o700. Like os.MkdirAll the mode is subject to the umask and
+// existing directories are left as they are.
+func WithDirMode(mode os.FileMode) Option {
+	return func(c *config) {
+		c.dirMode = mode
+	}
+}
+
+// WithMaxLineSize sets the longest line in bytes the line reader accepts instead
+// of the default bufio.MaxScanTokenSize. Longer lines fail with
+// bufio.ErrTooLong.
+func WithMaxLineSize(size int) Option {
+	return func(c *config) {
+		c.maxLineSize = size
+	}
+}
+
+// WithGzipLevel sets the compression level of NewGzipWriter, see the
+// compress/gzip constants. It defaults to gzip.DefaultCompression.
+func WithGzipLevel(level int) Option {
+	return func(c *config) {
+		c.gzipLevel = level
+	}
+}
+
+// WithZstdLevel sets the compression level of NewZstdWriter. It defaults to
+// zstd.SpeedDefault.
+func WithZstdLevel(level zstd.EncoderLevel) Option {
+	return func(c *config) {
+		c.zstdLevel = level
+	}
+}
+
+// WithMaxBackups sets how many rotated files NewRotatingWriter keeps. With 0
+// the current file is discarded on rotation.
+func WithMaxBackups(n int) Option {
+	return func(c *config) {
+		c.maxBackups = n
+	}
+}
+
+// WithBackupSuffix sets the suffix appended to the backup name of
+// NewBackupWriter instead of the default ".bak".
+func WithBackupSuffix(suffix string) Option {
+	return func(c *config) {
+		c.backupSuffix = suffix
+	}
+}
+
+// WithCSVDelimiter sets the field separator of ReadCSV and CSVRows, e.g. ';',
+// instead of the default comma.
+func WithCSVDelimiter(delimiter rune) Option {
+	return func(c *config) {
+		c.csvDelimiter = delimiter
+	}
+}
+
+// WithCSVHeader makes ReadCSV and CSVRows skip the first row as header.
+func WithCSVHeader() Option {
+	return func(c *config) {
+		c.csvHeader = true
+	}
+}
+
+// WithStopOnDecodeError ends DecodeNDJSON after the first line that fails to
+// decode instead of continuing with the next line.
+func WithStopOnDecodeError() Option {
+	return func(c *config) {
+		c.stopOnDecodeError = true
+	}
+}
+
+// WithExpandPath makes the constructors and functions taking a path expand a
+// leading "~" and environment variables in it, see ExpandPath.
+func WithExpandPath() Option {
+	return func(c *config) {
+		c.expand = true
+	}
+}
+
+// WithStripBOM removes a leading UTF-8 byte order mark from the content read,
+// files without one are read unchanged.
+func WithStripBOM() Option {
+	return func(c *config) {
+		c.stripBOM = true
+	}
+}
+
+// WithFS makes the File read and write through fsys instead of the operating
+// system's file system, e.g. a MemFS in tests. Writers always create or truncate
+// the file, the other writer modes like atomic or append writes need a real file
+// system.
+func WithFS(fsys FileSystem) Option {
+	return func(c *config) {
+		c.fsys = fsys
+	}
+}
@@ -0,0 +1,70 @@
+package file
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// PositionReader counts the bytes, lines and columns read from a file. Counting
+// happens as bytes are consumed from the file, so when a parser buffers its input
+// the position is the one of the buffered data, not of the parsed token.
+type PositionReader struct {
+	reader io.Reader
+	offset int64
+	line   int
+	column int
+}
+
+func (p *PositionReader) Read(b []byte) (int, error) {
+	n, err := p.reader.Read(b)
+	read := b[:n]
+	if lines := bytes.Count(read, []byte{'\n'}); lines > 0 {
+		p.line += lines
+		p.column = n - bytes.LastIndexByte(read, '\n')
+	} else {
+		p.column += n
+	}
+	p.offset += int64(n)
+	return n, err
+}
+
+// Close closes the wrapped reader if it is an io.Closer.
+func (p *PositionReader) Close() error {
+	return closeIfCloser(p.reader)
+}
+
+// Offset returns the number of bytes read.
+func (p *PositionReader) Offset() int64 {
+	return p.offset
+}
+
+// Line returns the 1-based line of the next byte to read.
+func (p *PositionReader) Line() int {
+	return p.line
+}
+
+// Column returns the 1-based column of the next byte to read.
+func (p *PositionReader) Column() int {
+	return p.column
+}
+
+// String returns the position as "line:column".
+func (p *PositionReader) String() string {
+	return fmt.Sprintf("%d:%d", p.line, p.column)
+}
+
+// PositionTracker wraps the lazily opened reader of the file so the position of
+// all following reads can be queried, e.g. to report where parsing failed.
+func (f *File) PositionTracker() (*PositionReader, error) {
+	reader, err := f.lazyReader()
+	if err != nil {
+		return nil, err
+	}
+	if p, ok := reader.(*PositionReader); ok {
+		return p, nil
+	}
+	p := &PositionReader{reader: reader, line: 1, column: 1}
+	f.Reader = p
+	return p, nil
+}
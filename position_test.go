@@ -0,0 +1,79 @@
+package file_test
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPositionTracker(t *testing.T) {
+	t.Parallel()
+	f := file.NewReader(strings.NewReader("ab\ncde\nf"))
+
+	pos, err := f.PositionTracker()
+	require.NoError(t, err)
+	assert.Equal(t, "1:1", pos.String())
+
+	// Read byte by byte and check the position of the next byte
+	r := iotest.OneByteReader(f.Reader)
+	want := []string{"1:2", "1:3", "2:1", "2:2", "2:3", "2:4", "3:1", "3:2"}
+	for i, w := range want {
+		_, err := r.Read(make([]byte, 1))
+		require.NoError(t, err)
+		assert.Equal(t, w, pos.String())
+		assert.Equal(t, int64(i+1), pos.Offset())
+	}
+	assert.Equal(t, 3, pos.Line())
+	assert.Equal(t, 2, pos.Column())
+
+	// Tracking again returns the same tracker
+	again, err := f.PositionTracker()
+	require.NoError(t, err)
+	assert.Same(t, pos, again)
+}
+
+// @markdown
+// TestPositionTrackerParseError illustrates how to report the position of a parse error.
+func TestPositionTrackerParseError(t *testing.T) {
+	t.Parallel()
+	f := file.New(createFile(t, "{\n  \"name\": \"go-file\",\n  \"version\": 1.2.3\n}\n"))
+
+	pos, err := f.PositionTracker()
+	require.NoError(t, err)
+
+	var v map[string]any
+	err = f.Decode(func(r io.Reader) error {
+		return json.NewDecoder(iotest.OneByteReader(r)).Decode(&v)
+	})
+	require.Error(t, err)
+	assert.Equal(t, 3, pos.Line())
+	assert.Equal(t, "3:18", pos.String())
+}
+
+func TestPositionTrackerReadBlock(t *testing.T) {
+	t.Parallel()
+	f := file.NewReader(strings.NewReader("line one\nline two\nline"))
+
+	pos, err := f.PositionTracker()
+	require.NoError(t, err)
+
+	cnt, err := f.Read()
+	require.NoError(t, err)
+	assert.Len(t, cnt, 22)
+	assert.Equal(t, "3:5", pos.String())
+	assert.Equal(t, int64(22), pos.Offset())
+}
+
+func TestPositionTrackerOpenError(t *testing.T) {
+	t.Parallel()
+	_, err := file.New("nonexistent.txt").PositionTracker()
+	assert.True(t, os.IsNotExist(err))
+}
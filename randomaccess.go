@@ -0,0 +1,49 @@
+package file
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// NewRandomAccess returns a File for random-access writes with WriteAt. Unlike
+// NewWriter it opens filePath for reading and writing without truncating it and
+// creates it if it doesn't exist.
+func NewRandomAccess(filePath string, opts ...Option) *File {
+	cfg := newConfig(opts)
+	cfg.randomAccess = true
+	return cfg.newFile(filePath)
+}
+
+// WriteAt implements the io.WriterAt interface by writing p at offset off of the
+// lazily created writer. Use it with NewRandomAccess, as NewWriter truncates the
+// file. Writers that don't implement io.WriterAt fail with
+// errors.ErrUnsupported.
+func (f *File) WriteAt(p []byte, off int64) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w, err := f.lazyWriter()
+	if err != nil {
+		return 0, err
+	}
+	wa, ok := w.Writer.(io.WriterAt)
+	if !ok {
+		return 0, fmt.Errorf("failed to write at offset %d to writer of type %T: %w", off, w.Writer, errors.ErrUnsupported)
+	}
+	return wa.WriteAt(p, off)
+}
+
+// ReadAt implements the io.ReaderAt interface by reading len(p) bytes at offset
+// off of the lazily opened reader. Readers that don't implement io.ReaderAt fail
+// with errors.ErrUnsupported.
+func (f *File) ReadAt(p []byte, off int64) (int, error) {
+	reader, err := f.lazyReader()
+	if err != nil {
+		return 0, err
+	}
+	ra, ok := reader.(io.ReaderAt)
+	if !ok {
+		return 0, fmt.Errorf("failed to read at offset %d from reader of type %T: %w", off, reader, errors.ErrUnsupported)
+	}
+	return ra.ReadAt(p, off)
+}
@@ -0,0 +1,63 @@
+package file_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// @markdown
+// TestWriteAt illustrates how to overwrite a record in the middle of a file.
+func TestWriteAt(t *testing.T) {
+	t.Parallel()
+	filePath := createFile(t, "0123456789")
+
+	f := file.NewRandomAccess(filePath)
+	n, err := f.WriteAt([]byte("XYZ"), 5)
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+	require.NoError(t, f.Close())
+
+	cnt, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, "01234XYZ89", string(cnt))
+}
+
+func TestWriteAtUnsupported(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	_, err := file.NewWriterBuffer(&buf, "records.db").WriteAt([]byte("XYZ"), 5)
+	require.ErrorIs(t, err, errors.ErrUnsupported)
+}
+
+func TestReadAt(t *testing.T) {
+	t.Parallel()
+	f := file.New(createFile(t, "0123456789"))
+
+	p := make([]byte, 4)
+	n, err := f.ReadAt(p, 6)
+	require.NoError(t, err)
+	assert.Equal(t, 4, n)
+	assert.Equal(t, "6789", string(p))
+	require.NoError(t, f.Close())
+}
+
+func TestReadAtErrors(t *testing.T) {
+	t.Parallel()
+	_, err := file.NewReader(io.MultiReader(strings.NewReader("0123456789"))).ReadAt(make([]byte, 4), 6)
+	require.ErrorIs(t, err, errors.ErrUnsupported)
+
+	_, err = file.New("nonexistent.txt").ReadAt(make([]byte, 4), 6)
+	assert.True(t, os.IsNotExist(err))
+
+	_, err = file.New(createFile(t, "0123")).ReadAt(make([]byte, 4), 2)
+	require.ErrorIs(t, err, io.EOF)
+}
@@ -0,0 +1,101 @@
+package file
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// RangeReader returns a reader over the inclusive byte range [start, end] of the
+// file. The reader uses its own file handle which is released on Close. Files
+// whose reader decompresses or otherwise transforms the content fail with
+// ErrNotSeekable, use Range for their decoded content.
+func (f *File) RangeReader(start, end int64) (io.ReadCloser, error) {
+	filePath := f.path()
+	if filePath == "" {
+		return nil, ErrNoPath
+	}
+	cfg := f.config()
+	if cfg.fsys == nil && !cfg.readsRaw(filePath) {
+		return nil, fmt.Errorf("decoded content of %q: %w", filePath, ErrNotSeekable)
+	}
+	fh, err := cfg.open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	info, err := fh.Stat()
+	if err != nil {
+		return nil, errors.Join(err, fh.Close())
+	}
+	if start < 0 || end < start || end >= info.Size() {
+		err = fmt.Errorf("range %d-%d of %q with size %d: %w", start, end, filePath, info.Size(), ErrInvalidRange)
+		return nil, errors.Join(err, fh.Close())
+	}
+	if _, err := fh.Seek(start, io.SeekStart); err != nil {
+		return nil, errors.Join(err, fh.Close())
+	}
+	return readCloser{Reader: io.LimitReader(fh, end-start+1), Closer: fh}, nil
+}
+
+// Range returns the inclusive byte range [start, end] of the file, e.g. for HTTP
+// range requests. The range is clamped to the end of the file, so a range past
+// the end returns the available bytes and a start beyond the end returns no
+// bytes. Files with a path are read with ReadAt from their own file handle, the
+// decoded content of Files whose reader decompresses or otherwise transforms it
+// is read from the start with a reader of its own. Neither moves the reader of
+// the File. In-memory readers must implement io.ReaderAt or
+// ErrNotSeekable is returned.
+func (f *File) Range(start, end int64) (cnt []byte, err error) {
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("range %d-%d: %w", start, end, ErrInvalidRange)
+	}
+	n := end - start
+	if n < math.MaxInt64 {
+		n++
+	}
+	var ra io.ReaderAt
+	filePath := f.path()
+	switch {
+	case filePath != "" && f.config().readsRaw(filePath):
+		fh, err := f.config().open(filePath)
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			err = errors.Join(err, fh.Close())
+		}()
+		ra = fh
+	case filePath != "":
+		// The decoded content can only be read from the start, with a reader
+		// of its own so the reader of the File isn't consumed.
+		reader, err := readerFunc(filePath, f.config())()
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			err = errors.Join(err, closeIfCloser(reader))
+		}()
+		if _, err := io.CopyN(io.Discard, reader, start); err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		return io.ReadAll(io.LimitReader(reader, n))
+	default:
+		reader, err := f.lazyReader()
+		if err != nil {
+			return nil, err
+		}
+		var ok bool
+		if ra, ok = reader.(io.ReaderAt); !ok {
+			return nil, fmt.Errorf("reader of type %T: %w", reader, ErrNotSeekable)
+		}
+	}
+	// The section reader stops at the end of the file without allocating the
+	// whole requested range up front.
+	return io.ReadAll(io.NewSectionReader(ra, start, n))
+}
@@ -0,0 +1,120 @@
+package file_test
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRangeReader(t *testing.T) {
+	t.Parallel()
+	f := file.New(createFile(t, "Hello, World!"))
+
+	r, err := f.RangeReader(7, 11)
+	require.NoError(t, err)
+
+	cnt, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "World", string(cnt))
+	require.NoError(t, r.Close())
+
+	r, err = f.RangeReader(0, 12)
+	require.NoError(t, err)
+	cnt, err = io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, World!", string(cnt))
+	require.NoError(t, r.Close())
+}
+
+func TestRangeReaderInvalidRange(t *testing.T) {
+	t.Parallel()
+	f := file.New(createFile(t, "Hello, World!"))
+
+	for _, rng := range [][2]int64{{-1, 4}, {5, 4}, {0, 13}, {20, 30}} {
+		_, err := f.RangeReader(rng[0], rng[1])
+		assert.ErrorIs(t, err, file.ErrInvalidRange, "range %v", rng)
+	}
+}
+
+func TestRangeReaderErrors(t *testing.T) {
+	t.Parallel()
+	_, err := file.New("nonexistent.txt").RangeReader(0, 1)
+	assert.True(t, os.IsNotExist(err))
+
+	_, err = file.NewReader(strings.NewReader("Hello, World!")).RangeReader(0, 1)
+	assert.ErrorIs(t, err, file.ErrNoPath)
+
+	// The raw bytes of a compressed file don't match the decoded content
+	_, err = file.NewCompressedReader(createGzipFile(t, "Hello, World!")).RangeReader(0, 1)
+	assert.ErrorIs(t, err, file.ErrNotSeekable)
+}
+
+func TestRange(t *testing.T) {
+	t.Parallel()
+	f := file.New(createFile(t, "Hello, World!"))
+
+	cnt, err := f.Range(7, 11)
+	require.NoError(t, err)
+	assert.Equal(t, "World", string(cnt))
+
+	// The end is clamped to the file size
+	cnt, err = f.Range(7, 100)
+	require.NoError(t, err)
+	assert.Equal(t, "World!", string(cnt))
+	cnt, err = f.Range(0, math.MaxInt64)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, World!", string(cnt))
+
+	// A start beyond the end returns no bytes
+	cnt, err = f.Range(20, 30)
+	require.NoError(t, err)
+	assert.Empty(t, cnt)
+
+	// In-memory readers implementing io.ReaderAt work as well
+	cnt, err = file.NewReader(strings.NewReader("Hello, World!")).Range(0, 4)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello", string(cnt))
+}
+
+func TestRangeDecompressed(t *testing.T) {
+	t.Parallel()
+	f := file.NewCompressedReader(createGzipFile(t, "Hello, World!"))
+
+	// The range refers to the decompressed content
+	cnt, err := f.Range(7, 11)
+	require.NoError(t, err)
+	assert.Equal(t, "World", string(cnt))
+
+	// Each range reads on its own and leaves the reader of the File untouched
+	cnt, err = f.Range(0, 4)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello", string(cnt))
+	content, err := f.ReadString()
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, World!", content)
+	require.NoError(t, f.Close())
+
+	cnt, err = file.NewCompressedReader(createGzipFile(t, "Hello, World!")).Range(20, 30)
+	require.NoError(t, err)
+	assert.Empty(t, cnt)
+}
+
+func TestRangeErrors(t *testing.T) {
+	t.Parallel()
+	_, err := file.New(createFile(t, "Hello, World!")).Range(5, 2)
+	require.ErrorIs(t, err, file.ErrInvalidRange)
+
+	_, err = file.NewReader(bytes.NewBufferString("Hello, World!")).Range(0, 4)
+	require.ErrorIs(t, err, file.ErrNotSeekable)
+
+	_, err = file.New("nonexistent.txt").Range(0, 4)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
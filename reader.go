@@ -0,0 +1,50 @@
+package file
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// readsRaw reports whether the reader of filePath yields the bytes of the file on
+// disk unchanged, so fast paths may read the file directly instead.
+func (c *config) readsRaw(filePath string) bool {
+	transformed := c.decompress && strings.HasSuffix(filePath, ".gz") ||
+		c.autoDecompress || c.zstdDecompress || c.stripBOM || c.normalizeNewlines
+	return c.fsys == nil && !transformed
+}
+
+// wrapReader layers the configured reader features on top of the opened file.
+func (c *config) wrapReader(filePath string, r io.Reader) (io.Reader, error) {
+	if c.decompress && strings.HasSuffix(filePath, ".gz") {
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("failed to read gzip header of %q: %w", filePath, err), closeIfCloser(r))
+		}
+		r = &gzipReader{Reader: gz, src: r}
+	}
+	if c.autoDecompress {
+		var err error
+		if r, err = sniffGzip(filePath, r); err != nil {
+			return nil, err
+		}
+	}
+	if c.zstdDecompress {
+		zr, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("failed to create zstd reader for %q: %w", filePath, err), closeIfCloser(r))
+		}
+		r = &zstdReader{Decoder: zr, src: r}
+	}
+	if c.stripBOM {
+		r = &bomReader{src: r}
+	}
+	if c.normalizeNewlines {
+		r = &newlineReader{src: r}
+	}
+	return r, nil
+}
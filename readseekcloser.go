@@ -0,0 +1,55 @@
+package file
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+type readSeekCloser struct {
+	io.ReadSeeker
+	io.Closer
+}
+
+// ReadSeekCloser returns a seekable reader of the file together with its size,
+// e.g. to hand the file to archive/zip. For Files with a path the reader uses its
+// own file handle, which also implements io.ReaderAt and is released on Close.
+// For in-memory Files and Files of WithFS the reader is returned if it
+// implements io.Seeker and otherwise ErrNotSeekable is returned, see
+// SeekableReader. Files whose reader decompresses or otherwise transforms the
+// content fail with ErrNotSeekable as the decoded content can't be seeked.
+func (f *File) ReadSeekCloser() (io.ReadSeekCloser, int64, error) {
+	if cfg := f.config(); f.FilePath != "" && cfg.fsys == nil {
+		if !cfg.readsRaw(f.FilePath) {
+			return nil, 0, fmt.Errorf("decoded content of %q: %w", f.FilePath, ErrNotSeekable)
+		}
+		fh, err := cfg.open(f.FilePath)
+		if err != nil {
+			return nil, 0, err
+		}
+		info, err := fh.Stat()
+		if err != nil {
+			return nil, 0, errors.Join(err, fh.Close())
+		}
+		return fh, info.Size(), nil
+	}
+	reader, err := f.lazyReader()
+	if err != nil {
+		return nil, 0, err
+	}
+	rs, ok := reader.(io.ReadSeeker)
+	if !ok {
+		return nil, 0, fmt.Errorf("reader of type %T: %w", reader, ErrNotSeekable)
+	}
+	size, err := rs.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, 0, err
+	}
+	if _, err := rs.Seek(0, io.SeekStart); err != nil {
+		return nil, 0, err
+	}
+	if rsc, ok := rs.(io.ReadSeekCloser); ok {
+		return rsc, size, nil
+	}
+	return readSeekCloser{ReadSeeker: rs, Closer: io.NopCloser(rs)}, size, nil
+}
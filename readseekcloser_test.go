@@ -0,0 +1,87 @@
+package file_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// @markdown
+// TestReadSeekCloser illustrates how to open a zip archive from a File.
+func TestReadSeekCloser(t *testing.T) {
+	t.Parallel()
+	archive := filepath.Join(t.TempDir(), "archive.zip")
+	fh, err := os.Create(archive)
+	require.NoError(t, err)
+	zw := zip.NewWriter(fh)
+	w, err := zw.Create("hello.txt")
+	require.NoError(t, err)
+	_, err = w.Write([]byte("Hello, World!"))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	require.NoError(t, fh.Close())
+
+	rsc, size, err := file.New(archive).ReadSeekCloser()
+	require.NoError(t, err)
+	defer rsc.Close()
+
+	ra, ok := rsc.(io.ReaderAt)
+	require.True(t, ok)
+	zr, err := zip.NewReader(ra, size)
+	require.NoError(t, err)
+	require.Len(t, zr.File, 1)
+	r, err := zr.File[0].Open()
+	require.NoError(t, err)
+	cnt, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, World!", string(cnt))
+}
+
+func TestReadSeekCloserInMemory(t *testing.T) {
+	t.Parallel()
+	rsc, size, err := file.NewReader(bytes.NewReader([]byte("Hello, World!"))).ReadSeekCloser()
+	require.NoError(t, err)
+	assert.Equal(t, int64(13), size)
+	_, err = rsc.Seek(7, io.SeekStart)
+	require.NoError(t, err)
+	cnt, err := io.ReadAll(rsc)
+	require.NoError(t, err)
+	assert.Equal(t, "World!", string(cnt))
+	require.NoError(t, rsc.Close())
+}
+
+func TestReadSeekCloserWithFS(t *testing.T) {
+	t.Parallel()
+	fsys := file.NewMemFS()
+	w := file.NewWriter("app.json", file.WithFS(fsys))
+	_, err := w.WriteString("Hello, World!")
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	rsc, size, err := file.New("app.json", file.WithFS(fsys)).ReadSeekCloser()
+	require.NoError(t, err)
+	assert.Equal(t, int64(13), size)
+	require.NoError(t, rsc.Close())
+}
+
+func TestReadSeekCloserError(t *testing.T) {
+	t.Parallel()
+	_, _, err := file.NewReader(io.MultiReader(strings.NewReader("x"))).ReadSeekCloser()
+	require.ErrorIs(t, err, file.ErrNotSeekable)
+
+	// The decompressed content can't be seeked
+	_, _, err = file.NewCompressedReader(createGzipFile(t, "Hello, World!")).ReadSeekCloser()
+	require.ErrorIs(t, err, file.ErrNotSeekable)
+
+	_, _, err = file.New("nonexistent.zip").ReadSeekCloser()
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
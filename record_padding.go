@@ -0,0 +1,44 @@
+package file
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync/atomic"
+)
+
+// paddingWriter writes every Write as one record of exactly size bytes.
+type paddingWriter struct {
+	io.WriteCloser
+	size     int
+	pad      byte
+	truncate bool
+	buf      []byte
+	// written counts the padded bytes, which Write doesn't report.
+	written atomic.Int64
+}
+
+func (p *paddingWriter) Write(b []byte) (int, error) {
+	if len(b) > p.size && !p.truncate {
+		return 0, fmt.Errorf("%d bytes exceed the record size of %d bytes: %w", len(b), p.size, ErrRecordTooLong)
+	}
+	if p.buf == nil {
+		p.buf = make([]byte, p.size)
+	}
+	n := copy(p.buf, b)
+	copy(p.buf[n:], bytes.Repeat([]byte{p.pad}, p.size-n))
+	written, err := p.WriteCloser.Write(p.buf)
+	p.written.Add(int64(written))
+	if err != nil {
+		return min(written, n), err
+	}
+	return len(b), nil
+}
+
+func (p *paddingWriter) bytesWritten() int64 {
+	return p.written.Load()
+}
+
+func (p *paddingWriter) Unwrap() io.Writer {
+	return p.WriteCloser
+}
@@ -0,0 +1,56 @@
+package file_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// @markdown
+// TestRecordPadding illustrates how to write a file of fixed-size records.
+func TestRecordPadding(t *testing.T) {
+	t.Parallel()
+	filePath := filepath.Join(t.TempDir(), "records.db")
+	const size = 8
+
+	f := file.NewWriter(filePath, file.WithRecordPadding(size, 0))
+	for _, rec := range []string{"one", "two", "three"} {
+		n, err := f.Write([]byte(rec))
+		require.NoError(t, err)
+		assert.Equal(t, len(rec), n)
+	}
+	require.NoError(t, f.Close())
+	assert.Equal(t, int64(3*size), f.Writer.BytesWritten())
+
+	cnt, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Len(t, cnt, 3*size)
+	assert.Equal(t, "three\x00\x00\x00", string(cnt[2*size:]))
+}
+
+func TestRecordPaddingTooLong(t *testing.T) {
+	t.Parallel()
+	filePath := filepath.Join(t.TempDir(), "records.db")
+
+	f := file.NewWriter(filePath, file.WithRecordPadding(4, ' '))
+	_, err := f.Write([]byte("too long"))
+	require.ErrorIs(t, err, file.ErrRecordTooLong)
+	require.NoError(t, f.Close())
+
+	f = file.NewWriter(filePath, file.WithRecordPadding(4, ' '), file.WithTruncateRecords())
+	n, err := f.Write([]byte("too long"))
+	require.NoError(t, err)
+	assert.Equal(t, 8, n)
+	_, err = f.Write([]byte("ok"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	cnt, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, "too ok  ", string(cnt))
+}
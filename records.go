@@ -0,0 +1,157 @@
+package file
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+)
+
+const (
+	// recordHeaderSize is the size of the length and checksum in front of a record.
+	recordHeaderSize = 8
+	// maxRecordSize guards ReadRecords against allocating for garbage lengths.
+	maxRecordSize = 1 << 30
+)
+
+// AppendRecord appends data as a length-prefixed and checksummed record to the
+// file and fsyncs it before returning, so the record survives a crash once
+// AppendRecord succeeded. A torn final record left behind by a crash is cut off
+// first, so the new record isn't lost behind it. AppendRecord is safe for
+// concurrent use and holds an exclusive advisory lock on the file while
+// appending, so other processes using AppendRecord don't interleave. The record
+// headers are only walked up to where the File verified them on the last append.
+// Use ReadRecords to replay the records.
+func (f *File) AppendRecord(data []byte) error {
+	if f.FilePath == "" {
+		return ErrNoPath
+	}
+	if len(data) > maxRecordSize {
+		return fmt.Errorf("record of %d bytes exceeds the maximum of %d bytes", len(data), maxRecordSize)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	cfg := f.config()
+	if err := cfg.mkdirParent(f.FilePath); err != nil {
+		return err
+	}
+	fh, err := cfg.openFile(f.FilePath, os.O_RDWR|os.O_APPEND|os.O_CREATE, cfg.filePerm())
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+	closeFile := fh.Close
+	// Without file locks, e.g. on plan9, only f.mu serializes the appends.
+	if err := lockFD(fh); err == nil {
+		closeFile = func() error {
+			return errors.Join(unlockFD(fh), fh.Close())
+		}
+	} else if !errors.Is(err, errors.ErrUnsupported) {
+		return errors.Join(fmt.Errorf("failed to lock %q: %w", f.FilePath, err), fh.Close())
+	}
+	end, err := f.appendRecord(fh, data)
+	if err != nil {
+		f.recordsEnd = 0
+		return errors.Join(err, closeFile())
+	}
+	f.recordsEnd = end
+	return closeFile()
+}
+
+// appendRecord cuts off a torn final record of fh, appends data as a record and
+// returns the offset behind it.
+func (f *File) appendRecord(fh *os.File, data []byte) (int64, error) {
+	info, err := fh.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("failed to stat %q: %w", f.FilePath, err)
+	}
+	// Another process may have appended since, or the file was cut behind the
+	// verified records.
+	from := f.recordsEnd
+	if from > info.Size() {
+		from = 0
+	}
+	end, err := recordsEnd(fh, from, info.Size())
+	if err != nil {
+		return 0, fmt.Errorf("failed to find the end of the records in %q: %w", f.FilePath, err)
+	}
+	if end < info.Size() {
+		if err := fh.Truncate(end); err != nil {
+			return 0, fmt.Errorf("failed to cut off torn record of %q: %w", f.FilePath, err)
+		}
+	}
+	record := make([]byte, recordHeaderSize+len(data))
+	binary.BigEndian.PutUint32(record[0:4], uint32(len(data))) //nolint:gosec // bounded by maxRecordSize
+	binary.BigEndian.PutUint32(record[4:8], crc32.ChecksumIEEE(data))
+	copy(record[recordHeaderSize:], data)
+	if _, err := fh.Write(record); err != nil {
+		return 0, fmt.Errorf("failed to append record to %q: %w", f.FilePath, err)
+	}
+	if err := fh.Sync(); err != nil {
+		return 0, fmt.Errorf("failed to sync %q: %w", f.FilePath, err)
+	}
+	return end + int64(len(record)), nil
+}
+
+// recordsEnd returns the offset behind the last complete record of fh with the
+// given size, walking the record headers from the record starting at offset.
+func recordsEnd(fh *os.File, offset, size int64) (int64, error) {
+	header := make([]byte, recordHeaderSize)
+	end := offset
+	for end+recordHeaderSize <= size {
+		if _, err := fh.ReadAt(header, end); err != nil {
+			return 0, err
+		}
+		length := int64(binary.BigEndian.Uint32(header[0:4]))
+		if length > maxRecordSize {
+			return 0, fmt.Errorf("record at offset %d has invalid size %d: %w", end, length, ErrCorruptRecord)
+		}
+		if end+recordHeaderSize+length > size {
+			break
+		}
+		end += recordHeaderSize + length
+	}
+	return end, nil
+}
+
+// ReadRecords calls fn for every record written by AppendRecord in order. A torn
+// final record left behind by a crash during AppendRecord ends the replay without
+// an error. A record with a checksum mismatch fails with ErrCorruptRecord.
+func (f *File) ReadRecords(fn func([]byte) error) error {
+	reader, err := f.lazyReader()
+	if err != nil {
+		return err
+	}
+	br := bufio.NewReader(reader)
+	header := make([]byte, recordHeaderSize)
+	for index := 0; ; index++ {
+		if _, err := io.ReadFull(br, header); err != nil {
+			return ignoreTornRecord(err)
+		}
+		size := binary.BigEndian.Uint32(header[0:4])
+		if size > maxRecordSize {
+			return fmt.Errorf("record %d has invalid size %d: %w", index, size, ErrCorruptRecord)
+		}
+		data := make([]byte, size)
+		if _, err := io.ReadFull(br, data); err != nil {
+			return ignoreTornRecord(err)
+		}
+		if crc32.ChecksumIEEE(data) != binary.BigEndian.Uint32(header[4:8]) {
+			return fmt.Errorf("record %d has invalid checksum: %w", index, ErrCorruptRecord)
+		}
+		if err := fn(data); err != nil {
+			return err
+		}
+	}
+}
+
+// ignoreTornRecord treats the end of the file, even in the middle of a record,
+// as the regular end of the record log.
+func ignoreTornRecord(err error) error {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,127 @@
+package file_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readRecords(t *testing.T, f *file.File) ([]string, error) {
+	t.Helper()
+	var records []string
+	err := f.ReadRecords(func(data []byte) error {
+		records = append(records, string(data))
+		return nil
+	})
+	return records, err
+}
+
+// @markdown
+// TestAppendRecord illustrates how to use a File as a crash-safe write-ahead log.
+func TestAppendRecord(t *testing.T) {
+	t.Parallel()
+	walPath := filepath.Join(t.TempDir(), "wal", "events.log")
+
+	wal := file.New(walPath)
+	for _, event := range []string{"created", "", "updated", "deleted"} {
+		require.NoError(t, wal.AppendRecord([]byte(event)))
+	}
+
+	records, err := readRecords(t, file.New(walPath))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"created", "", "updated", "deleted"}, records)
+}
+
+func TestReadRecordsTornRecord(t *testing.T) {
+	t.Parallel()
+	walPath := filepath.Join(t.TempDir(), "events.log")
+	wal := file.New(walPath)
+	require.NoError(t, wal.AppendRecord([]byte("created")))
+	require.NoError(t, wal.AppendRecord([]byte("updated")))
+
+	info, err := os.Stat(walPath)
+	require.NoError(t, err)
+
+	// Simulate a crash in the middle of writing the payload and the header
+	for _, size := range []int64{info.Size() - 3, info.Size() - 12} {
+		require.NoError(t, os.Truncate(walPath, size))
+		records, err := readRecords(t, file.New(walPath))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"created"}, records)
+	}
+}
+
+func TestAppendRecordAfterTornRecord(t *testing.T) {
+	t.Parallel()
+	walPath := filepath.Join(t.TempDir(), "events.log")
+	wal := file.New(walPath)
+	require.NoError(t, wal.AppendRecord([]byte("created")))
+	require.NoError(t, wal.AppendRecord([]byte("updated")))
+
+	// Simulate a crash in the middle of writing the second record
+	info, err := os.Stat(walPath)
+	require.NoError(t, err)
+	require.NoError(t, os.Truncate(walPath, info.Size()-3))
+
+	require.NoError(t, wal.AppendRecord([]byte("deleted")))
+	records, err := readRecords(t, file.New(walPath))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"created", "deleted"}, records)
+}
+
+func TestReadRecordsCorrupt(t *testing.T) {
+	t.Parallel()
+	walPath := filepath.Join(t.TempDir(), "events.log")
+	require.NoError(t, file.New(walPath).AppendRecord([]byte("created")))
+
+	cnt, err := os.ReadFile(walPath)
+	require.NoError(t, err)
+	cnt[len(cnt)-1] ^= 0xff
+	require.NoError(t, os.WriteFile(walPath, cnt, 0o600))
+
+	_, err = readRecords(t, file.New(walPath))
+	require.ErrorIs(t, err, file.ErrCorruptRecord)
+}
+
+func TestAppendRecordErrors(t *testing.T) {
+	t.Parallel()
+	err := file.NewReader(strings.NewReader("")).AppendRecord([]byte("created"))
+	require.ErrorIs(t, err, file.ErrNoPath)
+
+	_, err = readRecords(t, file.New("nonexistent.log"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestAppendRecordConcurrent(t *testing.T) {
+	t.Parallel()
+	walPath := filepath.Join(t.TempDir(), "events.log")
+	wal := file.New(walPath)
+	other := file.New(walPath)
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		// Half of the appenders use another File, like another process would
+		w := wal
+		if i%2 == 1 {
+			w = other
+		}
+		wg.Go(func() {
+			for j := range 10 {
+				assert.NoError(t, w.AppendRecord([]byte(fmt.Sprintf("event-%d-%d", i, j))))
+			}
+		})
+	}
+	wg.Wait()
+
+	records, err := readRecords(t, file.New(walPath))
+	require.NoError(t, err)
+	assert.Len(t, records, 200)
+}
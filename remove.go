@@ -0,0 +1,17 @@
+package file
+
+import "errors"
+
+// Remove closes the open reader and writer of the file and deletes it. Closing
+// first avoids leaking the handles and is required on Windows, where open files
+// can't be deleted.
+func (f *File) Remove() error {
+	filePath := f.path()
+	if filePath == "" {
+		return ErrNoPath
+	}
+	if err := errors.Join(f.closeReader(), f.closeWriter()); err != nil {
+		return err
+	}
+	return f.config().remove(filePath)
+}
@@ -0,0 +1,55 @@
+package file_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemove(t *testing.T) {
+	t.Parallel()
+	filePath := createFile(t, "Hello, World!")
+
+	f := file.New(filePath)
+	cnt, err := f.Read()
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, World!", string(cnt))
+
+	require.NoError(t, f.Remove())
+	assert.Nil(t, f.Reader)
+	_, err = os.Stat(filePath)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestRemoveWriter(t *testing.T) {
+	t.Parallel()
+	filePath := filepath.Join(t.TempDir(), "output.log")
+
+	f := file.NewWriter(filePath)
+	_, err := f.Write([]byte("Hello, World!"))
+	require.NoError(t, err)
+
+	require.NoError(t, f.Remove())
+	_, err = os.Stat(filePath)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestRemoveError(t *testing.T) {
+	t.Parallel()
+	err := file.New("nonexistent.txt").Remove()
+	assert.True(t, os.IsNotExist(err))
+
+	var buf bytes.Buffer
+	err = file.NewWriterBuffer(&buf, "").Remove()
+	require.ErrorIs(t, err, file.ErrNoPath)
+
+	err = file.NewReader(strings.NewReader("Hello, World!")).Remove()
+	require.ErrorIs(t, err, file.ErrNoPath)
+}
@@ -0,0 +1,19 @@
+package file
+
+import "time"
+
+// retryBackoff is the delay before the first retry, it doubles with every retry.
+const retryBackoff = 10 * time.Millisecond
+
+// retry calls fn until it succeeds, fails with an error that is not retryable or
+// the configured attempts are used up.
+func retry[T any](cfg *config, fn func() (T, error)) (T, error) {
+	v, err := fn()
+	backoff := retryBackoff
+	for attempt := 1; err != nil && cfg.retryable != nil && cfg.retryable(err) && attempt < cfg.retryAttempts; attempt++ {
+		time.Sleep(backoff)
+		backoff *= 2
+		v, err = fn()
+	}
+	return v, err
+}
@@ -0,0 +1,54 @@
+package file_test
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func isNotExist(err error) bool {
+	return errors.Is(err, fs.ErrNotExist)
+}
+
+func TestWithRetry(t *testing.T) {
+	t.Parallel()
+	filePath := filepath.Join(t.TempDir(), "late.txt")
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		_ = os.WriteFile(filePath, []byte("Hello, World!"), 0o600)
+	}()
+
+	f := file.New(filePath, file.WithRetry(10, isNotExist))
+	cnt, err := f.Read()
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, World!", string(cnt))
+}
+
+func TestWithRetryGivesUp(t *testing.T) {
+	t.Parallel()
+	f := file.New(filepath.Join(t.TempDir(), "missing.txt"), file.WithRetry(3, isNotExist))
+
+	start := time.Now()
+	_, err := f.Read()
+	require.ErrorIs(t, err, fs.ErrNotExist)
+	// Two retries with 10ms and 20ms backoff
+	assert.GreaterOrEqual(t, time.Since(start), 30*time.Millisecond)
+}
+
+func TestWithRetryNotRetryable(t *testing.T) {
+	t.Parallel()
+	f := file.New(filepath.Join(t.TempDir(), "missing.txt"), file.WithRetry(100, file.IsTextFileBusy))
+
+	start := time.Now()
+	_, err := f.Read()
+	require.ErrorIs(t, err, fs.ErrNotExist)
+	assert.Less(t, time.Since(start), 10*time.Millisecond)
+}
@@ -0,0 +1,178 @@
+package file
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// NewInRoot returns a File for name inside root. Reading and writing go through
+// root, so names like "../../etc/passwd" that escape root fail.
+func NewInRoot(root *os.Root, name string, opts ...Option) *File {
+	cfg := newConfig(opts)
+	cfg.root = root
+	return cfg.newFile(name)
+}
+
+// NewSafeWriter returns a File like NewWriter for relPath below root. Paths that
+// are absolute or escape root after cleaning, like "../etc/passwd", are rejected
+// with ErrUnsafePath, so relPath may come from user input. Symbolic links below
+// root are followed, use NewInRoot to prevent escaping through them.
+func NewSafeWriter(root, relPath string, opts ...Option) (*File, error) {
+	cfg := newConfig(opts)
+	// Expand before the check, so variables can't smuggle in a "..".
+	relPath, err := cfg.expandPath(relPath)
+	if err != nil {
+		return nil, err
+	}
+	if !filepath.IsLocal(relPath) {
+		return nil, fmt.Errorf("%q: %w", relPath, ErrUnsafePath)
+	}
+	cfg.expand = false
+	return cfg.newFile(filepath.Join(root, filepath.Clean(relPath))), nil
+}
+
+// open opens name for reading, inside the root if one is configured.
+func (c *config) open(name string) (*os.File, error) {
+	if c.fsys != nil {
+		return nil, c.errFS("open", name)
+	}
+	if c.root != nil {
+		return c.root.Open(name)
+	}
+	return os.Open(name)
+}
+
+// readFile reads the whole content of name, inside the root if one is
+// configured.
+func (c *config) readFile(name string) (cnt []byte, err error) {
+	fh, err := c.open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, fh.Close())
+	}()
+	return io.ReadAll(fh)
+}
+
+// stat returns the FileInfo of name, through the FileSystem of WithFS or inside
+// the root if one is configured.
+func (c *config) stat(name string) (os.FileInfo, error) {
+	if c.fsys != nil {
+		return c.fsys.Stat(name)
+	}
+	if c.root != nil {
+		return c.root.Stat(name)
+	}
+	return os.Stat(name)
+}
+
+// remove deletes name, inside the root if one is configured.
+func (c *config) remove(name string) error {
+	if c.fsys != nil {
+		return c.errFS("remove", name)
+	}
+	if c.root != nil {
+		return c.root.Remove(name)
+	}
+	return os.Remove(name)
+}
+
+// rename renames oldname to newname, inside the root if one is configured.
+func (c *config) rename(oldname, newname string) error {
+	if c.fsys != nil {
+		return c.errFS("rename", oldname)
+	}
+	if c.root != nil {
+		return c.root.Rename(oldname, newname)
+	}
+	return os.Rename(oldname, newname)
+}
+
+// create creates or truncates name, inside the root if one is configured.
+func (c *config) create(name string) (*os.File, error) {
+	return c.openFile(name, os.O_RDWR|os.O_CREATE|os.O_TRUNC, c.filePerm())
+}
+
+// openFile opens name with flag and perm, inside the root if one is configured.
+func (c *config) openFile(name string, flag int, perm os.FileMode) (*os.File, error) {
+	if c.fsys != nil {
+		return nil, c.errFS("open", name)
+	}
+	if c.root != nil {
+		return c.root.OpenFile(name, flag, perm)
+	}
+	return os.OpenFile(name, flag, perm)
+}
+
+// mkdirAll creates dir and its parents, through the FileSystem of WithFS or
+// inside the root if one is configured.
+func (c *config) mkdirAll(dir string) error {
+	if c.fsys != nil {
+		return c.fsys.MkdirAll(dir, c.dirPerm())
+	}
+	if c.root != nil {
+		return c.root.MkdirAll(dir, c.dirPerm())
+	}
+	return os.MkdirAll(dir, c.dirPerm())
+}
+
+// mkdirParent creates the directory of filePath and its parents.
+func (c *config) mkdirParent(filePath string) error {
+	dir := filepath.Dir(filePath)
+	if err := c.mkdirAll(dir); err != nil {
+		return fmt.Errorf("%w %q: %w", ErrCreateDir, dir, err)
+	}
+	return nil
+}
+
+// chmodPath changes the mode of name, inside the root if one is configured.
+func (c *config) chmodPath(name string, mode os.FileMode) error {
+	if c.fsys != nil {
+		return c.errFS("chmod", name)
+	}
+	if c.root != nil {
+		return c.root.Chmod(name, mode)
+	}
+	return os.Chmod(name, mode)
+}
+
+// chtimes changes the access and modification times of name, inside the root if
+// one is configured.
+func (c *config) chtimes(name string, atime, mtime time.Time) error {
+	if c.fsys != nil {
+		return c.errFS("change times of", name)
+	}
+	if c.root != nil {
+		return c.root.Chtimes(name, atime, mtime)
+	}
+	return os.Chtimes(name, atime, mtime)
+}
+
+// errFS returns the error for an operation on name that the FileSystem of WithFS
+// doesn't support.
+func (c *config) errFS(op, name string) error {
+	return fmt.Errorf("failed to %s %q on file system of type %T: %w", op, name, c.fsys, errors.ErrUnsupported)
+}
+
+// filePerm returns the permissions for created files, 0o666 before the umask
+// unless WithFileMode is set.
+func (c *config) filePerm() os.FileMode {
+	if c.fileMode != 0 {
+		return c.fileMode
+	}
+	return 0o666
+}
+
+// dirPerm returns the permissions for created directories, os.ModePerm before the
+// umask unless WithDirMode is set.
+func (c *config) dirPerm() os.FileMode {
+	if c.dirMode != 0 {
+		return c.dirMode
+	}
+	return os.ModePerm
+}
@@ -0,0 +1,144 @@
+package file_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// @markdown
+// TestNewInRoot illustrates how to safely access user provided paths below a root directory.
+func TestNewInRoot(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	root, err := os.OpenRoot(dir)
+	require.NoError(t, err)
+	defer root.Close()
+
+	f := file.NewInRoot(root, "uploads/avatar.txt")
+
+	exists, err := f.Exists()
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	_, err = f.Write([]byte("Hello, World!"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	cnt, err := os.ReadFile(filepath.Join(dir, "uploads", "avatar.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, World!", string(cnt))
+
+	f = file.NewInRoot(root, "uploads/avatar.txt")
+	exists, err = f.Exists()
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	cnt, err = f.Read()
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, World!", string(cnt))
+	require.NoError(t, f.Close())
+}
+
+func TestNewInRootTraversal(t *testing.T) {
+	t.Parallel()
+	parent := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(parent, "secret.txt"), []byte("secret"), 0o600))
+	dir := filepath.Join(parent, "root")
+	require.NoError(t, os.Mkdir(dir, 0o755))
+	root, err := os.OpenRoot(dir)
+	require.NoError(t, err)
+	defer root.Close()
+
+	_, err = file.NewInRoot(root, "../secret.txt").Read()
+	require.Error(t, err)
+
+	_, err = file.NewInRoot(root, "../escaped.txt").Write([]byte("Hello, World!"))
+	require.Error(t, err)
+	_, err = os.Stat(filepath.Join(parent, "escaped.txt"))
+	assert.True(t, os.IsNotExist(err))
+
+	_, err = file.NewInRoot(root, "../../escaped/file.txt").Write([]byte("Hello, World!"))
+	require.Error(t, err)
+}
+
+func TestNewInRootEscape(t *testing.T) {
+	t.Parallel()
+	parent := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(parent, "secret.txt"), []byte("secret"), 0o600))
+	dir := filepath.Join(parent, "root")
+	require.NoError(t, os.Mkdir(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "input.txt"), []byte("input"), 0o600))
+	root, err := os.OpenRoot(dir)
+	require.NoError(t, err)
+	defer root.Close()
+
+	_, err = file.NewInRoot(root, "../secret.txt").RangeReader(0, 1)
+	require.Error(t, err)
+
+	_, err = file.NewInRoot(root, "../secret.txt").ReadStable(time.Millisecond)
+	require.Error(t, err)
+
+	_, err = file.NewInRoot(root, "../secret.txt").IsLocked()
+	require.Error(t, err)
+
+	err = file.NewInRoot(root, "../escaped.txt").WriteCAS([]byte("Hello, World!"), time.Time{})
+	require.Error(t, err)
+
+	err = file.NewInRoot(root, "../escaped.txt").AppendRecord([]byte("Hello, World!"))
+	require.Error(t, err)
+
+	err = file.NewInRoot(root, "input.txt").Move("../escaped.txt")
+	require.Error(t, err)
+	assert.FileExists(t, filepath.Join(dir, "input.txt"))
+
+	entries, err := os.ReadDir(parent)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2, "only secret.txt and root are expected in %s", parent)
+}
+
+func TestNewInRootSidecar(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	root, err := os.OpenRoot(dir)
+	require.NoError(t, err)
+	defer root.Close()
+
+	f := file.NewInRoot(root, "data.txt", file.WithSidecarChecksum("sha256"))
+	_, err = f.Write([]byte("Hello, World!"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	assert.FileExists(t, filepath.Join(dir, "data.txt.sha256"))
+}
+
+func TestNewSafeWriter(t *testing.T) {
+	t.Parallel()
+	root := t.TempDir()
+
+	f, err := file.NewSafeWriter(root, "uploads/../avatars/./me.png")
+	require.NoError(t, err)
+	_, err = f.Write([]byte("png"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	cnt, err := os.ReadFile(filepath.Join(root, "avatars", "me.png"))
+	require.NoError(t, err)
+	assert.Equal(t, "png", string(cnt))
+}
+
+func TestNewSafeWriterTraversal(t *testing.T) {
+	t.Parallel()
+	root := t.TempDir()
+
+	for _, relPath := range []string{"../escape.txt", "uploads/../../escape.txt", filepath.Join(root, "abs.txt"), ""} {
+		_, err := file.NewSafeWriter(root, relPath)
+		require.ErrorIs(t, err, file.ErrUnsafePath, relPath)
+	}
+}
@@ -0,0 +1,113 @@
+package file
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strconv"
+)
+
+// defaultMaxBackups is the number of rotated files NewRotatingWriter keeps.
+const defaultMaxBackups = 3
+
+// NewRotatingWriter returns a File that appends to filePath and rotates it once
+// a write would grow it beyond maxBytes. On rotation filePath is renamed to
+// filePath.1, an existing filePath.1 to filePath.2 and so on, and writing
+// continues in a fresh filePath. A single write larger than maxBytes is not
+// split. WithMaxBackups sets how many rotated files are kept, 3 by default. A
+// maxBytes that isn't positive fails the writes, the existing file is kept.
+func NewRotatingWriter(filePath string, maxBytes int64, opts ...Option) *File {
+	if maxBytes <= 0 {
+		return newErrorFile(fmt.Errorf("invalid rotation size %d of %q", maxBytes, filePath))
+	}
+	cfg := newConfig(append([]Option{WithMaxBackups(defaultMaxBackups)}, opts...))
+	cfg.maxBytes, cfg.rotating = maxBytes, true
+	return cfg.newFile(filePath)
+}
+
+// rotatingFile is the writer of NewRotatingWriter.
+type rotatingFile struct {
+	*os.File
+	cfg      *config
+	filePath string
+	size     int64
+}
+
+func newRotatingFile(filePath string, cfg *config) (*rotatingFile, error) {
+	r := &rotatingFile{cfg: cfg, filePath: filePath}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// open opens the current file for appending.
+func (r *rotatingFile) open() error {
+	fh, err := r.cfg.openFile(r.filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, r.cfg.filePerm())
+	if err != nil {
+		return err
+	}
+	if err := r.cfg.chmod(fh); err != nil {
+		return errors.Join(err, fh.Close())
+	}
+	info, err := fh.Stat()
+	if err != nil {
+		return errors.Join(err, fh.Close())
+	}
+	r.File, r.size = fh, info.Size()
+	return nil
+}
+
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	if r.size > 0 && r.size+int64(len(p)) > r.cfg.maxBytes {
+		if err := r.rotate(); err != nil {
+			return 0, fmt.Errorf("failed to rotate %q: %w", r.filePath, err)
+		}
+	}
+	n, err := r.File.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// WriteString is like Write, it shadows the promoted method of *os.File that
+// would bypass the rotation.
+func (r *rotatingFile) WriteString(s string) (int, error) {
+	return r.Write([]byte(s))
+}
+
+// ReadFrom copies src through Write, so the promoted fast path of *os.File
+// doesn't bypass the rotation.
+func (r *rotatingFile) ReadFrom(src io.Reader) (int64, error) {
+	return io.Copy(struct{ io.Writer }{r}, src)
+}
+
+// rotate shifts the backups by one, moves the current file to the first backup
+// and opens a fresh file.
+func (r *rotatingFile) rotate() error {
+	if err := r.File.Close(); err != nil {
+		return err
+	}
+	backups := r.cfg.maxBackups
+	if backups == 0 {
+		if err := r.cfg.remove(r.filePath); err != nil {
+			return err
+		}
+		return r.open()
+	}
+	for i := backups - 1; i > 0; i-- {
+		err := r.cfg.rename(r.backup(i), r.backup(i+1))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	if err := r.cfg.rename(r.filePath, r.backup(1)); err != nil {
+		return err
+	}
+	return r.open()
+}
+
+func (r *rotatingFile) backup(i int) string {
+	return r.filePath + "." + strconv.Itoa(i)
+}
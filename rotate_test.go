@@ -0,0 +1,104 @@
+package file_test
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readDirContents(t *testing.T, dir string) map[string]string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	contents := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		cnt, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		require.NoError(t, err)
+		contents[entry.Name()] = string(cnt)
+	}
+	return contents
+}
+
+// @markdown
+// TestNewRotatingWriter illustrates how to write a log file that is rotated at a size limit.
+func TestNewRotatingWriter(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+
+	f := file.NewRotatingWriter(filepath.Join(dir, "app.log"), 10)
+	for _, line := range []string{"first\n", "second\n", "third\n"} {
+		_, err := f.Write([]byte(line))
+		require.NoError(t, err)
+	}
+	require.NoError(t, f.Close())
+
+	assert.Equal(t, map[string]string{
+		"app.log":   "third\n",
+		"app.log.1": "second\n",
+		"app.log.2": "first\n",
+	}, readDirContents(t, dir))
+}
+
+func TestNewRotatingWriterInvalidSize(t *testing.T) {
+	t.Parallel()
+	for _, maxBytes := range []int64{0, -1} {
+		filePath := createFile(t, "existing log\n")
+
+		// The existing log is neither truncated nor appended to
+		f := file.NewRotatingWriter(filePath, maxBytes)
+		_, err := f.Write([]byte("line\n"))
+		require.ErrorContains(t, err, "invalid rotation size")
+		require.NoError(t, f.Close())
+
+		cnt, err := os.ReadFile(filePath)
+		require.NoError(t, err)
+		assert.Equal(t, "existing log\n", string(cnt))
+	}
+}
+
+func TestNewRotatingWriterReadFrom(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+
+	f := file.NewRotatingWriter(filepath.Join(dir, "app.log"), 10)
+	_, err := f.WriteString("first\n")
+	require.NoError(t, err)
+	_, err = f.ReadFrom(strings.NewReader("second\n"))
+	require.NoError(t, err)
+	_, err = io.Copy(f, strings.NewReader("third\n"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	assert.Equal(t, map[string]string{
+		"app.log":   "third\n",
+		"app.log.1": "second\n",
+		"app.log.2": "first\n",
+	}, readDirContents(t, dir))
+}
+
+func TestNewRotatingWriterMaxBackups(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "app.log")
+	require.NoError(t, os.WriteFile(filePath, []byte("old\n"), 0o600))
+
+	f := file.NewRotatingWriter(filePath, 8, file.WithMaxBackups(1))
+	for _, line := range []string{"a\n", "bbbb\n", "cccc\n", "dd\n"} {
+		_, err := f.Write([]byte(line))
+		require.NoError(t, err)
+	}
+	require.NoError(t, f.Close())
+
+	// The existing file is appended to and only one backup is kept
+	assert.Equal(t, map[string]string{
+		"app.log":   "cccc\ndd\n",
+		"app.log.1": "bbbb\n",
+	}, readDirContents(t, dir))
+}
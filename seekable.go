@@ -0,0 +1,96 @@
+package file
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// seekableBuffer copies a stream into a temp file on first access and serves
+// all reads from that copy.
+type seekableBuffer struct {
+	src  io.Reader
+	once sync.Once
+	tmp  *os.File
+	err  error
+}
+
+func (s *seekableBuffer) buffer() error {
+	s.once.Do(func() {
+		tmp, err := os.CreateTemp("", "go-file-seekable-*")
+		if err != nil {
+			s.err = errors.Join(fmt.Errorf("failed to create temp file: %w", err), closeIfCloser(s.src))
+			return
+		}
+		_, err = io.Copy(tmp, s.src)
+		if err == nil {
+			_, err = tmp.Seek(0, io.SeekStart)
+		}
+		err = errors.Join(err, closeIfCloser(s.src))
+		s.src = nil
+		if err != nil {
+			s.err = errors.Join(fmt.Errorf("failed to buffer stream: %w", err), tmp.Close(), os.Remove(tmp.Name()))
+			return
+		}
+		s.tmp = tmp
+	})
+	return s.err
+}
+
+func (s *seekableBuffer) Read(p []byte) (int, error) {
+	if err := s.buffer(); err != nil {
+		return 0, err
+	}
+	return s.tmp.Read(p)
+}
+
+func (s *seekableBuffer) Seek(offset int64, whence int) (int64, error) {
+	if err := s.buffer(); err != nil {
+		return 0, err
+	}
+	return s.tmp.Seek(offset, whence)
+}
+
+func (s *seekableBuffer) ReadAt(p []byte, off int64) (int, error) {
+	if err := s.buffer(); err != nil {
+		return 0, err
+	}
+	return s.tmp.ReadAt(p, off)
+}
+
+func (s *seekableBuffer) Close() error {
+	if s.tmp == nil {
+		return closeIfCloser(s.src)
+	}
+	return errors.Join(s.tmp.Close(), os.Remove(s.tmp.Name()))
+}
+
+func closeIfCloser(r io.Reader) error {
+	if closer, ok := r.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// SeekableReader returns a File that supports io.Seeker and io.ReaderAt for a
+// source that may only be streamed, e.g. an HTTP body. On first access the whole
+// stream is copied into a temp file in os.TempDir, so it costs disk space and time
+// proportional to the stream size. The source reader is owned by the returned
+// File and the temp file is removed on Close.
+func SeekableReader(f *File) (*File, error) {
+	src, err := f.lazyReader()
+	if err != nil {
+		return nil, err
+	}
+	f.Reader = nil
+	buf := &seekableBuffer{src: src}
+	load := func() (io.Reader, error) {
+		return buf, nil
+	}
+	return &File{
+		Reader: buf,
+		reader: sync.OnceValues(load),
+	}, nil
+}
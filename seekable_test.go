@@ -0,0 +1,96 @@
+package file_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeekableReader(t *testing.T) {
+	t.Parallel()
+	// Only the io.Reader interface is exposed, so it can't be seeked
+	src := file.NewReader(io.MultiReader(strings.NewReader("Hello, World!")))
+
+	f, err := file.SeekableReader(src)
+	require.NoError(t, err)
+
+	seeker, ok := f.Reader.(io.ReadSeeker)
+	require.True(t, ok)
+
+	_, err = seeker.Seek(7, io.SeekStart)
+	require.NoError(t, err)
+	cnt, err := io.ReadAll(seeker)
+	require.NoError(t, err)
+	assert.Equal(t, "World!", string(cnt))
+
+	readerAt, ok := f.Reader.(io.ReaderAt)
+	require.True(t, ok)
+	p := make([]byte, 5)
+	_, err = readerAt.ReadAt(p, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello", string(p))
+
+	require.NoError(t, f.Close())
+}
+
+func TestSeekableReaderZip(t *testing.T) {
+	t.Parallel()
+	var archive bytes.Buffer
+	zw := zip.NewWriter(&archive)
+	w, err := zw.Create("hello.txt")
+	require.NoError(t, err)
+	_, err = w.Write([]byte("Hello, World!"))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	size := int64(archive.Len())
+
+	f, err := file.SeekableReader(file.NewReader(io.NopCloser(&archive)))
+	require.NoError(t, err)
+	defer f.Close()
+
+	readerAt, ok := f.Reader.(io.ReaderAt)
+	require.True(t, ok)
+	zr, err := zip.NewReader(readerAt, size)
+	require.NoError(t, err)
+	require.Len(t, zr.File, 1)
+	assert.Equal(t, "hello.txt", zr.File[0].Name)
+}
+
+func TestSeekableReaderErrors(t *testing.T) {
+	t.Parallel()
+	_, err := file.SeekableReader(file.New("nonexistent.txt"))
+	assert.True(t, os.IsNotExist(err))
+
+	// A failing stream surfaces on first access
+	f, err := file.SeekableReader(file.NewReader(io.MultiReader(strings.NewReader("Hello"), iotest.ErrReader(io.ErrUnexpectedEOF))))
+	require.NoError(t, err)
+	_, err = f.Read()
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	require.NoError(t, f.Close())
+
+	// Closing an unread File closes the source
+	src := &closeRecorder{Reader: strings.NewReader("Hello")}
+	f, err = file.SeekableReader(file.NewReader(src))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	assert.True(t, src.closed)
+}
+
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return nil
+}
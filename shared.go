@@ -0,0 +1,47 @@
+package file
+
+import (
+	"io"
+)
+
+// AtomicAppendSize is the size up to which a single write to a file opened in
+// append mode is expected to not interleave with writes of other processes.
+// It matches PIPE_BUF on Linux. POSIX only guarantees this for pipes, for regular
+// files it holds for local file systems on Linux and BSD but not for network file
+// systems like NFS.
+const AtomicAppendSize = 4096
+
+// NewSharedAppendWriter returns a File that appends to filePath with O_APPEND, so
+// many processes can write to the same log without a lock as long as each record
+// is written with a single Write of at most AtomicAppendSize bytes. Use
+// WithAtomicChunks to split larger writes, which keeps every chunk intact but
+// may interleave the chunks of different writers.
+func NewSharedAppendWriter(filePath string, opts ...Option) *File {
+	cfg := newConfig(opts)
+	cfg.appendMode = true
+	return cfg.newFile(filePath)
+}
+
+// chunkedWriter splits writes into chunks of at most size bytes.
+type chunkedWriter struct {
+	io.WriteCloser
+	size int
+}
+
+func (c *chunkedWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		chunk := p[:min(len(p), c.size)]
+		n, err := c.WriteCloser.Write(chunk)
+		written += n
+		if err != nil {
+			return written, err
+		}
+		p = p[len(chunk):]
+	}
+	return written, nil
+}
+
+func (c *chunkedWriter) Unwrap() io.Writer {
+	return c.WriteCloser
+}
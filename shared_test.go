@@ -0,0 +1,83 @@
+package file_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// @markdown
+// TestNewSharedAppendWriter illustrates how several writers with their own handle append to one log.
+func TestNewSharedAppendWriter(t *testing.T) {
+	t.Parallel()
+	logPath := filepath.Join(t.TempDir(), "audit.log")
+	const writers, records = 8, 200
+
+	var wg sync.WaitGroup
+	for w := range writers {
+		wg.Go(func() {
+			// Every writer has its own handle like a separate process would
+			f := file.NewSharedAppendWriter(logPath)
+			defer f.Close()
+			for r := range records {
+				line := fmt.Sprintf("writer=%d record=%03d %s\n", w, r, strings.Repeat("x", 64))
+				_, err := f.Write([]byte(line))
+				assert.NoError(t, err)
+			}
+		})
+	}
+	wg.Wait()
+
+	lines, err := file.New(logPath).ReadLines()
+	require.NoError(t, err)
+	require.Len(t, lines, writers*records)
+	seen := map[string]bool{}
+	for _, line := range lines {
+		var w, r int
+		var payload string
+		_, err := fmt.Sscanf(line, "writer=%d record=%d %s", &w, &r, &payload)
+		require.NoError(t, err, line)
+		assert.Equal(t, strings.Repeat("x", 64), payload)
+		seen[line] = true
+	}
+	assert.Len(t, seen, writers*records)
+}
+
+func TestNewSharedAppendWriterKeepsContent(t *testing.T) {
+	t.Parallel()
+	logPath := createFile(t, "first\n")
+
+	f := file.NewSharedAppendWriter(logPath)
+	_, err := f.Write([]byte("second\n"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	cnt, err := os.ReadFile(logPath)
+	require.NoError(t, err)
+	assert.Equal(t, "first\nsecond\n", string(cnt))
+}
+
+func TestWithAtomicChunks(t *testing.T) {
+	t.Parallel()
+	logPath := filepath.Join(t.TempDir(), "audit.log")
+	record := bytes.Repeat([]byte("x"), 3*file.AtomicAppendSize+10)
+
+	f := file.NewSharedAppendWriter(logPath, file.WithAtomicChunks())
+	n, err := f.Write(record)
+	require.NoError(t, err)
+	assert.Equal(t, len(record), n)
+	require.NoError(t, f.Close())
+
+	cnt, err := os.ReadFile(logPath)
+	require.NoError(t, err)
+	assert.Equal(t, record, cnt)
+}
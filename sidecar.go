@@ -0,0 +1,45 @@
+package file
+
+import (
+	"fmt"
+	"hash"
+	"io"
+	"path/filepath"
+)
+
+// sidecarWriter hashes everything written and stores the digest in a checksum
+// file next to filePath once the file is closed.
+type sidecarWriter struct {
+	io.WriteCloser
+	cfg      *config
+	hash     hash.Hash
+	filePath string
+	algo     string
+}
+
+func (s *sidecarWriter) Write(p []byte) (int, error) {
+	n, err := s.WriteCloser.Write(p)
+	s.hash.Write(p[:n])
+	return n, err
+}
+
+func (s *sidecarWriter) Unwrap() io.Writer {
+	return s.WriteCloser
+}
+
+// Close closes the file and atomically writes the checksum file in the format
+// of sha256sum and friends.
+func (s *sidecarWriter) Close() error {
+	if err := s.WriteCloser.Close(); err != nil {
+		return err
+	}
+	// Nothing was committed after Abort of an atomic writer.
+	if a := s.cfg.atomicTemp; a != nil && a.discarded {
+		return nil
+	}
+	line := fmt.Sprintf("%x  %s\n", s.hash.Sum(nil), filepath.Base(s.filePath))
+	if err := s.cfg.writeAtomic(s.filePath+"."+s.algo, []byte(line), nil); err != nil {
+		return fmt.Errorf("failed to write checksum file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package file_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// @markdown
+// TestWithSidecarChecksum illustrates how to write a checksum file alongside the written file.
+func TestWithSidecarChecksum(t *testing.T) {
+	t.Parallel()
+	filePath := filepath.Join(t.TempDir(), "release.txt")
+
+	f := file.NewWriter(filePath, file.WithSidecarChecksum("sha256"))
+	_, err := f.Write([]byte("Hello, "))
+	require.NoError(t, err)
+	_, err = f.Write([]byte("World!"))
+	require.NoError(t, err)
+
+	// The checksum file is only written on Close
+	_, err = os.Stat(filePath + ".sha256")
+	require.True(t, os.IsNotExist(err))
+
+	require.NoError(t, f.Close())
+
+	cnt, err := os.ReadFile(filePath + ".sha256")
+	require.NoError(t, err)
+	assert.Equal(t, "dffd6021bb2bd5b0af676290809ec3a53191dd81c7f70a4b28688a362182986f  release.txt\n", string(cnt))
+}
+
+func TestWithSidecarChecksumMD5(t *testing.T) {
+	t.Parallel()
+	filePath := filepath.Join(t.TempDir(), "release.txt")
+
+	f := file.New(filePath, file.WithSidecarChecksum("md5"))
+	_, err := f.Write([]byte("Hello, World!"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	cnt, err := os.ReadFile(filePath + ".md5")
+	require.NoError(t, err)
+	assert.Equal(t, "65a8e27d8879283831b664bd8b7f0ad4  release.txt\n", string(cnt))
+}
+
+func TestWithSidecarChecksumUnsupported(t *testing.T) {
+	t.Parallel()
+	filePath := filepath.Join(t.TempDir(), "release.txt")
+
+	f := file.NewWriter(filePath, file.WithSidecarChecksum("crc32"))
+	_, err := f.Write([]byte("Hello, World!"))
+	assert.ErrorContains(t, err, "unsupported checksum algorithm")
+}
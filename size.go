@@ -0,0 +1,51 @@
+package file
+
+import (
+	"fmt"
+	"os"
+)
+
+// Stat returns the os.FileInfo of the file without reading it. It fails with
+// ErrNoPath for Files that are only backed by an in-memory reader.
+func (f *File) Stat() (os.FileInfo, error) {
+	filePath := f.path()
+	if filePath == "" {
+		return nil, ErrNoPath
+	}
+	return f.config().stat(filePath)
+}
+
+// Size returns the size of the file in bytes.
+func (f *File) Size() (int64, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+// SizeString returns the file size in a human-readable form like "1.5 MiB".
+// Use WithSIUnits to get decimal units like "1.6 MB" instead.
+func (f *File) SizeString(opts ...Option) (string, error) {
+	size, err := f.Size()
+	if err != nil {
+		return "", err
+	}
+	return formatSize(size, newConfig(opts).siUnits), nil
+}
+
+func formatSize(size int64, si bool) string {
+	unit, prefixes, suffix := int64(1024), "KMGTPE", "iB"
+	if si {
+		unit, prefixes, suffix = 1000, "kMGTPE", "B"
+	}
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := unit, 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %c%s", float64(size)/float64(div), prefixes[exp], suffix)
+}
@@ -0,0 +1,28 @@
+package file
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatSize(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		size int64
+		si   bool
+		want string
+	}{
+		{0, false, "0 B"},
+		{1023, false, "1023 B"},
+		{1024, false, "1.0 KiB"},
+		{1536 * 1024, false, "1.5 MiB"},
+		{5 << 40, false, "5.0 TiB"},
+		{999, true, "999 B"},
+		{1000, true, "1.0 kB"},
+		{3_200_000_000, true, "3.2 GB"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, formatSize(tt.size, tt.si), "size %d", tt.size)
+	}
+}
@@ -0,0 +1,90 @@
+package file_test
+
+import (
+	"crypto/sha256"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSizeString(t *testing.T) {
+	t.Parallel()
+	f := file.New(createFile(t, strings.Repeat("x", 1536)))
+
+	size, err := f.Size()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1536), size)
+
+	s, err := f.SizeString()
+	require.NoError(t, err)
+	assert.Equal(t, "1.5 KiB", s)
+
+	s, err = f.SizeString(file.WithSIUnits())
+	require.NoError(t, err)
+	assert.Equal(t, "1.5 kB", s)
+}
+
+func TestSizeStringErrors(t *testing.T) {
+	t.Parallel()
+	_, err := file.New("nonexistent.txt").SizeString()
+	assert.True(t, os.IsNotExist(err))
+
+	_, err = file.NewReader(strings.NewReader("Hello, World!")).SizeString()
+	assert.ErrorIs(t, err, file.ErrNoPath)
+}
+
+func TestStat(t *testing.T) {
+	t.Parallel()
+	f := file.New(createFile(t, "Hello, World!"))
+
+	info, err := f.Stat()
+	require.NoError(t, err)
+	assert.Equal(t, int64(13), info.Size())
+	assert.False(t, info.ModTime().IsZero())
+	assert.False(t, info.IsDir())
+}
+
+func TestStatWriters(t *testing.T) {
+	t.Parallel()
+	for name, newWriter := range map[string]func(string) *file.File{
+		"writer":   func(p string) *file.File { return file.NewWriter(p) },
+		"append":   func(p string) *file.File { return file.NewAppendWriter(p) },
+		"atomic":   func(p string) *file.File { return file.NewAtomicWriter(p) },
+		"locked":   func(p string) *file.File { return file.NewLockedWriter(p) },
+		"backup":   func(p string) *file.File { return file.NewBackupWriter(p) },
+		"buffered": func(p string) *file.File { return file.NewBufferedWriter(p, 1024) },
+		"limited":  func(p string) *file.File { return file.NewLimitedWriter(p, 1024) },
+		"hash":     func(p string) *file.File { return file.NewHashWriter(p, sha256.New()) },
+		"gzip":     func(p string) *file.File { return file.NewGzipWriter(p) },
+		"zstd":     func(p string) *file.File { return file.NewZstdWriter(p) },
+		"rotating": func(p string) *file.File { return file.NewRotatingWriter(p, 1024) },
+		"multi":    func(p string) *file.File { return file.NewMultiWriter(p) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			filePath := createFile(t, "Hello, World!")
+
+			// The path is known before the first write
+			f := newWriter(filePath)
+			assert.Equal(t, filePath, f.FilePath)
+			size, err := f.Size()
+			require.NoError(t, err)
+			assert.Equal(t, int64(13), size)
+		})
+	}
+}
+
+func TestStatErrors(t *testing.T) {
+	t.Parallel()
+	_, err := file.New("nonexistent.txt").Stat()
+	assert.True(t, os.IsNotExist(err))
+
+	_, err = file.NewReader(strings.NewReader("Hello, World!")).Stat()
+	require.ErrorIs(t, err, file.ErrNoPath)
+	assert.EqualError(t, err, "file has no path")
+}
@@ -0,0 +1,43 @@
+package file
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// ReadStable waits until the size and modification time of the file did not
+// change for the quiet duration and then reads it. Use WithTimeout to stop
+// waiting for a file that never settles.
+func (f *File) ReadStable(quiet time.Duration, opts ...Option) ([]byte, error) {
+	if f.FilePath == "" {
+		return nil, ErrNoPath
+	}
+	cfg := f.options(opts)
+	var deadline time.Time
+	if cfg.timeout > 0 {
+		deadline = time.Now().Add(cfg.timeout)
+	}
+	interval := max(quiet/4, time.Millisecond)
+
+	info, err := cfg.stat(f.FilePath)
+	if err != nil {
+		return nil, err
+	}
+	stableSince := time.Now()
+	for time.Since(stableSince) < quiet {
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			return nil, fmt.Errorf("file %q did not become stable within %s: %w", f.FilePath, cfg.timeout, os.ErrDeadlineExceeded)
+		}
+		time.Sleep(interval)
+		current, err := cfg.stat(f.FilePath)
+		if err != nil {
+			return nil, err
+		}
+		if current.Size() != info.Size() || !current.ModTime().Equal(info.ModTime()) {
+			info = current
+			stableSince = time.Now()
+		}
+	}
+	return f.Read()
+}
@@ -0,0 +1,70 @@
+package file_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func appendSlowly(t *testing.T, filePath string, chunks int, pause time.Duration) <-chan struct{} {
+	t.Helper()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fh, err := os.OpenFile(filePath, os.O_APPEND|os.O_WRONLY, 0o600)
+		if err != nil {
+			return
+		}
+		defer fh.Close()
+		for range chunks {
+			time.Sleep(pause)
+			_, _ = fh.WriteString("x")
+		}
+	}()
+	return done
+}
+
+func TestReadStable(t *testing.T) {
+	t.Parallel()
+	tmpFile := createFile(t, "")
+	done := appendSlowly(t, tmpFile, 5, 10*time.Millisecond)
+
+	f := file.New(tmpFile)
+	cnt, err := f.ReadStable(100 * time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Repeat("x", 5), string(cnt))
+	<-done
+}
+
+func TestReadStableTimeout(t *testing.T) {
+	t.Parallel()
+	tmpFile := createFile(t, "")
+	done := appendSlowly(t, tmpFile, 20, 5*time.Millisecond)
+
+	f := file.New(tmpFile)
+	_, err := f.ReadStable(time.Second, file.WithTimeout(30*time.Millisecond))
+	require.ErrorIs(t, err, os.ErrDeadlineExceeded)
+	<-done
+}
+
+func TestReadStableNoPath(t *testing.T) {
+	t.Parallel()
+	f := file.NewReader(strings.NewReader("Hello, World!"))
+
+	_, err := f.ReadStable(time.Millisecond)
+	require.ErrorIs(t, err, file.ErrNoPath)
+}
+
+func TestReadStableNonExistingFile(t *testing.T) {
+	t.Parallel()
+	f := file.New("nonexistent.txt")
+
+	_, err := f.ReadStable(time.Millisecond)
+	assert.True(t, os.IsNotExist(err))
+}
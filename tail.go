@@ -0,0 +1,78 @@
+package file
+
+import "bytes"
+
+// tailChunkSize is the size of the chunks Tail reads backwards from the end.
+const tailChunkSize = 4096
+
+// Tail returns the last n lines of the file without the line endings. Files with
+// a path are read backwards from the end in chunks, so only about the size of the
+// last n lines is read. In-memory Files and Files whose reader decompresses or
+// otherwise transforms the content are scanned completely while keeping only the
+// last n lines.
+func (f *File) Tail(n int) ([][]byte, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+	if f.FilePath == "" || !f.config().readsRaw(f.FilePath) {
+		return f.tailScan(n)
+	}
+	fh, err := f.config().open(f.FilePath)
+	if err != nil {
+		return nil, err
+	}
+	defer fh.Close()
+	info, err := fh.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	pos := info.Size()
+	for pos > 0 {
+		size := min(pos, tailChunkSize)
+		pos -= size
+		chunk := make([]byte, size, int(size)+len(data))
+		if _, err := fh.ReadAt(chunk, pos); err != nil {
+			return nil, err
+		}
+		data = append(chunk, data...)
+		// n complete lines need n line endings in front of the last line.
+		if bytes.Count(bytes.TrimSuffix(data, []byte("\n")), []byte("\n")) >= n {
+			break
+		}
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	lines := bytes.Split(bytes.TrimSuffix(data, []byte("\n")), []byte("\n"))
+	lines = lines[max(len(lines)-n, 0):]
+	for i, line := range lines {
+		lines[i] = bytes.TrimSuffix(line, []byte("\r"))
+	}
+	return lines, nil
+}
+
+// tailScanCap bounds the lines tailScan allocates up front for large n.
+const tailScanCap = 1024
+
+// tailScan keeps the last n lines of a full scan in a ring, which grows with the
+// scanned lines up to n.
+func (f *File) tailScan(n int) ([][]byte, error) {
+	ring := make([][]byte, 0, min(n, tailScanCap))
+	next := 0
+	err := f.scanLines(f.config(), func(line []byte) error {
+		line = bytes.Clone(line)
+		if len(ring) < n {
+			ring = append(ring, line)
+			return nil
+		}
+		ring[next] = line
+		next = (next + 1) % n
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append(ring[next:], ring[:next]...), nil
+}
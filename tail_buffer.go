@@ -0,0 +1,73 @@
+package file
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// ringBuffer holds the last len(buf) bytes written to it.
+type ringBuffer struct {
+	mu   sync.Mutex
+	buf  []byte
+	pos  int
+	full bool
+}
+
+func newRingBuffer(size int) *ringBuffer {
+	return &ringBuffer{buf: make([]byte, size)}
+}
+
+func (r *ringBuffer) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	size := len(r.buf)
+	if len(p) >= size {
+		copy(r.buf, p[len(p)-size:])
+		r.pos, r.full = 0, true
+		return len(p), nil
+	}
+	n := copy(r.buf[r.pos:], p)
+	if n < len(p) || r.pos+n == size {
+		r.full = true
+	}
+	copy(r.buf, p[n:])
+	r.pos = (r.pos + len(p)) % size
+	return len(p), nil
+}
+
+func (r *ringBuffer) Bytes() []byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.full {
+		return bytes.Clone(r.buf[:r.pos])
+	}
+	return append(bytes.Clone(r.buf[r.pos:]), r.buf[:r.pos]...)
+}
+
+// tailWriter mirrors all written bytes into a ring buffer.
+type tailWriter struct {
+	io.WriteCloser
+	ring *ringBuffer
+}
+
+func (t *tailWriter) Write(p []byte) (int, error) {
+	n, err := t.WriteCloser.Write(p)
+	_, _ = t.ring.Write(p[:n])
+	return n, err
+}
+
+func (t *tailWriter) Unwrap() io.Writer {
+	return t.WriteCloser
+}
+
+// TailBytes returns a copy of the most recently written bytes kept by
+// WithTailBuffer, or nil if the File has no tail buffer. It is safe to call
+// concurrently with Write.
+func (f *File) TailBytes() []byte {
+	tail := f.config().tail
+	if tail == nil {
+		return nil
+	}
+	return tail.Bytes()
+}
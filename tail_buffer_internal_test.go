@@ -0,0 +1,32 @@
+package file
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRingBuffer(t *testing.T) {
+	t.Parallel()
+	r := newRingBuffer(5)
+	assert.Empty(t, r.Bytes())
+
+	steps := []struct {
+		write string
+		want  string
+	}{
+		{"ab", "ab"},
+		{"cde", "abcde"},
+		{"f", "bcdef"},
+		{"ghi", "efghi"},
+		{"", "efghi"},
+		{"0123456789", "56789"},
+		{"abcde", "abcde"},
+	}
+	for _, step := range steps {
+		n, err := r.Write([]byte(step.write))
+		assert.NoError(t, err)
+		assert.Equal(t, len(step.write), n)
+		assert.Equal(t, step.want, string(r.Bytes()), "after writing %q", step.write)
+	}
+}
@@ -0,0 +1,47 @@
+package file_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// @markdown
+// TestWithTailBuffer illustrates how to keep the end of a written log in memory.
+func TestWithTailBuffer(t *testing.T) {
+	t.Parallel()
+	f := file.NewWriter(filepath.Join(t.TempDir(), "app.log"), file.WithTailBuffer(32))
+	defer f.Close()
+
+	// Nothing was written yet
+	assert.Empty(t, f.TailBytes())
+
+	for i := range 10 {
+		_, err := fmt.Fprintf(f, "line %d\n", i)
+		require.NoError(t, err)
+	}
+
+	tail := f.TailBytes()
+	assert.Len(t, tail, 32)
+	assert.True(t, strings.HasSuffix(string(tail), "line 8\nline 9\n"))
+
+	cnt, err := f.Read()
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(string(cnt), string(tail)))
+}
+
+func TestTailBytesWithoutTailBuffer(t *testing.T) {
+	t.Parallel()
+	f := file.NewWriter(filepath.Join(t.TempDir(), "app.log"))
+	defer f.Close()
+
+	_, err := f.Write([]byte("Hello, World!"))
+	require.NoError(t, err)
+	assert.Nil(t, f.TailBytes())
+}
@@ -0,0 +1,87 @@
+package file_test
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func toStrings(lines [][]byte) []string {
+	s := make([]string, 0, len(lines))
+	for _, line := range lines {
+		s = append(s, string(line))
+	}
+	return s
+}
+
+// @markdown
+// TestTail illustrates how to read the last lines of a large log file.
+func TestTail(t *testing.T) {
+	t.Parallel()
+	var sb strings.Builder
+	for i := range 10000 {
+		fmt.Fprintf(&sb, "line %d\n", i)
+	}
+	f := file.New(createFile(t, sb.String()))
+
+	lines, err := f.Tail(3)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"line 9997", "line 9998", "line 9999"}, toStrings(lines))
+}
+
+func TestTailShortFiles(t *testing.T) {
+	t.Parallel()
+	for name, tc := range map[string]struct {
+		cnt      string
+		expected []string
+	}{
+		"fewer lines":       {cnt: "one\ntwo\n", expected: []string{"one", "two"}},
+		"exact lines":       {cnt: "one\ntwo\nthree\n", expected: []string{"one", "two", "three"}},
+		"no final newline":  {cnt: "one\r\ntwo\r\nthree\r\nfour", expected: []string{"two", "three", "four"}},
+		"empty":             {cnt: "", expected: []string{}},
+		"empty lines":       {cnt: "\n\n\n\n", expected: []string{"", "", ""}},
+		"more than a chunk": {cnt: strings.Repeat("x", 5000) + "\nlast\n", expected: []string{strings.Repeat("x", 5000), "last"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			lines, err := file.New(createFile(t, tc.cnt)).Tail(3)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, toStrings(lines))
+
+			// In-memory Files give the same result
+			lines, err = file.NewReader(strings.NewReader(tc.cnt)).Tail(3)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, toStrings(lines))
+		})
+	}
+}
+
+func TestTailDecompressed(t *testing.T) {
+	t.Parallel()
+	// The lines of the decompressed content are returned, not of the gzip stream
+	f := file.NewCompressedReader(createGzipFile(t, "one\ntwo\nthree\n"))
+	lines, err := f.Tail(2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"two", "three"}, toStrings(lines))
+}
+
+func TestTailLargeN(t *testing.T) {
+	t.Parallel()
+	// n far beyond the number of lines doesn't allocate for n lines
+	lines, err := file.NewReader(strings.NewReader("one\ntwo\n")).Tail(math.MaxInt)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"one", "two"}, toStrings(lines))
+}
+
+func TestTailError(t *testing.T) {
+	t.Parallel()
+	_, err := file.New("nonexistent.txt").Tail(3)
+	assert.True(t, os.IsNotExist(err))
+}
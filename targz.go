@@ -0,0 +1,100 @@
+package file
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// TarGz writes a gzip compressed tar archive of srcDir to dstPath. Entries keep
+// their path relative to srcDir and their mode. The archive is written to a temp
+// file first and only renamed to dstPath once complete. Use WithFilter to choose
+// the entries to include.
+func TarGz(srcDir, dstPath string, opts ...Option) error {
+	cfg := newConfig(opts)
+	srcDir, err := cfg.expandPath(srcDir)
+	if err != nil {
+		return err
+	}
+	if dstPath, err = cfg.expandPath(dstPath); err != nil {
+		return err
+	}
+	write := func(w io.Writer) error {
+		gw := gzip.NewWriter(w)
+		tw := tar.NewWriter(gw)
+		err := addTarTree(tw, srcDir, dstPath, cfg)
+		return errors.Join(err, tw.Close(), gw.Close())
+	}
+	if err := cfg.writeAtomicFunc(dstPath, write, nil); err != nil {
+		return fmt.Errorf("failed to write archive %q: %w", dstPath, err)
+	}
+	return nil
+}
+
+func addTarTree(tw *tar.Writer, srcDir, dstPath string, cfg *config) error {
+	dstAbs, err := filepath.Abs(dstPath)
+	if err != nil {
+		return err
+	}
+	tmpPrefix := filepath.Join(filepath.Dir(dstAbs), "."+filepath.Base(dstAbs)+".tmp-")
+	return filepath.WalkDir(srcDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(srcDir, path)
+		if err != nil || rel == "." {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		// Never archive the archive itself when it is written into srcDir.
+		if abs, err := filepath.Abs(path); err == nil && (abs == dstAbs || strings.HasPrefix(abs, tmpPrefix)) {
+			return nil
+		}
+		if cfg.filter != nil && !cfg.filter(rel, d) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		return addTarEntry(tw, path, rel, d)
+	})
+}
+
+func addTarEntry(tw *tar.Writer, path, name string, d fs.DirEntry) error {
+	info, err := d.Info()
+	if err != nil {
+		return err
+	}
+	var link string
+	if info.Mode()&fs.ModeSymlink != 0 {
+		if link, err = os.Readlink(path); err != nil {
+			return err
+		}
+	}
+	hdr, err := tar.FileInfoHeader(info, link)
+	if err != nil {
+		return err
+	}
+	hdr.Name = name
+	if d.IsDir() {
+		hdr.Name += "/"
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	if !info.Mode().IsRegular() {
+		return nil
+	}
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, src)
+	return errors.Join(err, src.Close())
+}
@@ -0,0 +1,105 @@
+package file_test
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readTarGz(t *testing.T, archive string) map[string]string {
+	t.Helper()
+	fh, err := os.Open(archive)
+	require.NoError(t, err)
+	defer fh.Close()
+	gr, err := gzip.NewReader(fh)
+	require.NoError(t, err)
+	tr := tar.NewReader(gr)
+
+	entries := map[string]string{}
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return entries
+		}
+		require.NoError(t, err)
+		cnt, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		entries[hdr.Name] = string(cnt)
+		if hdr.Name == "bin/run.sh" {
+			assert.Equal(t, int64(0o755), hdr.Mode&0o777)
+		}
+	}
+}
+
+func createTree(t *testing.T) string {
+	t.Helper()
+	srcDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(srcDir, "bin"), 0o755))
+	require.NoError(t, os.MkdirAll(filepath.Join(srcDir, "tmp"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(srcDir, "README.md"), []byte("# readme"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(srcDir, "bin", "run.sh"), []byte("#!/bin/sh"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(srcDir, "tmp", "cache"), []byte("cache"), 0o600))
+	return srcDir
+}
+
+// @markdown
+// TestTarGz illustrates how to package a directory into a tar.gz archive.
+func TestTarGz(t *testing.T) {
+	t.Parallel()
+	srcDir := createTree(t)
+	archive := filepath.Join(t.TempDir(), "dist", "build.tar.gz")
+
+	err := file.TarGz(srcDir, archive)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		"README.md":  "# readme",
+		"bin/":       "",
+		"bin/run.sh": "#!/bin/sh",
+		"tmp/":       "",
+		"tmp/cache":  "cache",
+	}, readTarGz(t, archive))
+}
+
+func TestTarGzWithFilter(t *testing.T) {
+	t.Parallel()
+	srcDir := createTree(t)
+	// The archive is written into the archived directory itself
+	archive := filepath.Join(srcDir, "build.tar.gz")
+
+	err := file.TarGz(srcDir, archive, file.WithFilter(func(path string, _ fs.DirEntry) bool {
+		return !strings.HasPrefix(path, "tmp")
+	}))
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		"README.md":  "# readme",
+		"bin/":       "",
+		"bin/run.sh": "#!/bin/sh",
+	}, readTarGz(t, archive))
+}
+
+func TestTarGzMissingSource(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	archive := filepath.Join(dir, "build.tar.gz")
+
+	err := file.TarGz(filepath.Join(dir, "missing"), archive)
+	require.ErrorIs(t, err, fs.ErrNotExist)
+
+	// Neither the archive nor a temp file is left behind
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
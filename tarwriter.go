@@ -0,0 +1,91 @@
+package file
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// TarWriter writes a tar archive entry by entry from Files, see NewTarWriter.
+type TarWriter struct {
+	file *File
+	tw   *tar.Writer
+}
+
+// NewTarWriter returns a TarWriter that writes a tar archive to filePath, which
+// is created like by NewWriter on the first entry or on Close.
+func NewTarWriter(filePath string, opts ...Option) *TarWriter {
+	f := NewWriter(filePath, opts...)
+	return &TarWriter{file: f, tw: tar.NewWriter(f)}
+}
+
+// AddFile adds the content of src as the regular file name to the archive and
+// closes the reader of src. Size, mode and modification time are taken from
+// src.Stat. Files whose reader decompresses or otherwise transforms the content,
+// or which were already partly read, are read into memory for the size of their
+// content. In-memory Files without a path are read into memory for their size
+// and get mode 0o644 and the current time.
+func (t *TarWriter) AddFile(name string, src *File) error {
+	hdr, content, err := tarHeader(name, src)
+	if err != nil {
+		return fmt.Errorf("failed to add %q to tar: %w", name, err)
+	}
+	if err := t.tw.WriteHeader(hdr); err != nil {
+		return errors.Join(fmt.Errorf("failed to add %q to tar: %w", name, err), src.closeReader())
+	}
+	if _, err := io.Copy(t.tw, content); err != nil {
+		return errors.Join(fmt.Errorf("failed to add %q to tar: %w", name, err), src.closeReader())
+	}
+	return src.closeReader()
+}
+
+// tarHeader returns the header of the tar entry name for src and the reader of
+// its content.
+func tarHeader(name string, src *File) (*tar.Header, io.Reader, error) {
+	info, err := src.Stat()
+	if errors.Is(err, ErrNoPath) {
+		cnt, err := src.Read()
+		if err != nil {
+			return nil, nil, err
+		}
+		hdr := &tar.Header{Typeflag: tar.TypeReg, Name: name, Size: int64(len(cnt)), Mode: 0o644, ModTime: time.Now()}
+		return hdr, bytes.NewReader(cnt), nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if !info.Mode().IsRegular() {
+		return nil, nil, fmt.Errorf("%q is not a regular file", src.path())
+	}
+	hdr, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return nil, nil, err
+	}
+	hdr.Name = name
+	// The size on disk only matches the content of a fresh reader of the raw
+	// file, decoded or partly read content is read into memory for its size.
+	if src.Reader != nil || !src.config().readsRaw(src.path()) {
+		cnt, err := src.Read()
+		if err != nil {
+			return nil, nil, err
+		}
+		hdr.Size = int64(len(cnt))
+		return hdr, bytes.NewReader(cnt), nil
+	}
+	reader, err := src.lazyReader()
+	if err != nil {
+		return nil, nil, err
+	}
+	return hdr, reader, nil
+}
+
+// Close writes the end of the archive and closes the file.
+func (t *TarWriter) Close() error {
+	if err := t.tw.Close(); err != nil {
+		return errors.Join(fmt.Errorf("failed to close tar: %w", err), t.file.Close())
+	}
+	return t.file.Close()
+}
@@ -0,0 +1,80 @@
+package file_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// @markdown
+// TestNewTarWriter illustrates how to assemble a tar archive from Files.
+func TestNewTarWriter(t *testing.T) {
+	t.Parallel()
+	filePath := filepath.Join(t.TempDir(), "dist", "release.tar")
+	readme := createFile(t, "# Release")
+
+	tw := file.NewTarWriter(filePath)
+	require.NoError(t, tw.AddFile("README.md", file.New(readme)))
+	require.NoError(t, tw.AddFile("VERSION", file.NewReader(bytes.NewBufferString("v1.0.0"))))
+	require.NoError(t, tw.Close())
+
+	assert.Equal(t, map[string]string{"README.md": "# Release", "VERSION": "v1.0.0"}, readTarEntries(t, filePath))
+}
+
+func readTarEntries(t *testing.T, filePath string) map[string]string {
+	t.Helper()
+	fh, err := os.Open(filePath)
+	require.NoError(t, err)
+	defer fh.Close()
+	tr := tar.NewReader(fh)
+	entries := map[string]string{}
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return entries
+		}
+		require.NoError(t, err)
+		cnt, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		entries[hdr.Name] = string(cnt)
+	}
+}
+
+func TestNewTarWriterDecoded(t *testing.T) {
+	t.Parallel()
+	filePath := filepath.Join(t.TempDir(), "release.tar")
+	partly := file.New(createFile(t, "Hello, World!"))
+	_, err := partly.Seek(7, io.SeekStart)
+	require.NoError(t, err)
+
+	// The entry sizes follow the content, not the size on disk
+	tw := file.NewTarWriter(filePath)
+	require.NoError(t, tw.AddFile("decompressed.txt", file.NewCompressedReader(createGzipFile(t, "Hello, World!"))))
+	require.NoError(t, tw.AddFile("partly.txt", partly))
+	require.NoError(t, tw.Close())
+
+	assert.Equal(t, map[string]string{"decompressed.txt": "Hello, World!", "partly.txt": "World!"}, readTarEntries(t, filePath))
+}
+
+func TestNewTarWriterError(t *testing.T) {
+	t.Parallel()
+	tw := file.NewTarWriter(filepath.Join(t.TempDir(), "release.tar"))
+
+	err := tw.AddFile("missing.txt", file.New("nonexistent.txt"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	err = tw.AddFile("dir", file.New(t.TempDir()))
+	require.ErrorContains(t, err, "is not a regular file")
+
+	// An empty archive is still valid
+	require.NoError(t, tw.Close())
+}
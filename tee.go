@@ -0,0 +1,33 @@
+package file
+
+import (
+	"io"
+	"sync"
+)
+
+// Tee makes the following reads like Read, WriteTo or Lines also write the read
+// bytes to w, e.g. to log or cache the content while processing it. The reader is
+// still opened on first use. Errors writing to w fail the read. Reset drops the
+// tee.
+func (f *File) Tee(w io.Writer) {
+	tee := func(r io.Reader) io.Reader {
+		return readCloser{Reader: io.TeeReader(r, w), Closer: closeFunc(func() error {
+			return closeIfCloser(r)
+		})}
+	}
+	if f.Reader != nil {
+		f.Reader = tee(f.Reader)
+		return
+	}
+	if f.reader == nil {
+		return
+	}
+	open := f.reader
+	f.reader = sync.OnceValues(func() (io.Reader, error) {
+		r, err := open()
+		if err != nil {
+			return nil, err
+		}
+		return tee(r), nil
+	})
+}
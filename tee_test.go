@@ -0,0 +1,58 @@
+package file_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTee(t *testing.T) {
+	t.Parallel()
+	f := file.New(createFile(t, "Hello, World!"))
+
+	var buf bytes.Buffer
+	f.Tee(&buf)
+	// The file is only opened on the first read
+	assert.Nil(t, f.Reader)
+
+	cnt, err := f.Read()
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, World!", string(cnt))
+	assert.Equal(t, "Hello, World!", buf.String())
+	require.NoError(t, f.Close())
+}
+
+func TestTeeOpenedReader(t *testing.T) {
+	t.Parallel()
+	f := file.NewReader(strings.NewReader("Hello, World!"))
+
+	// The reader is already opened before Tee
+	_, err := f.Seek(0, io.SeekStart)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	f.Tee(&buf)
+
+	var out bytes.Buffer
+	n, err := f.WriteTo(&out)
+	require.NoError(t, err)
+	assert.Equal(t, int64(13), n)
+	assert.Equal(t, "Hello, World!", out.String())
+	assert.Equal(t, "Hello, World!", buf.String())
+}
+
+func TestTeeError(t *testing.T) {
+	t.Parallel()
+	f := file.New("nonexistent.txt")
+
+	var buf bytes.Buffer
+	f.Tee(&buf)
+	_, err := f.Read()
+	require.Error(t, err)
+	assert.Empty(t, buf.String())
+}
@@ -0,0 +1,16 @@
+//go:build unix || windows || wasip1
+
+package file
+
+import (
+	"errors"
+	"syscall"
+)
+
+// IsTextFileBusy reports whether err is ETXTBSY. Linux returns it when a file is
+// opened for writing while it is executed or executed while it is still open for
+// writing, which happens transiently in build pipelines that write and then run a
+// binary. Other platforms rarely or never report it. Use it with WithRetry.
+func IsTextFileBusy(err error) bool {
+	return errors.Is(err, syscall.ETXTBSY)
+}
@@ -0,0 +1,25 @@
+package file_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsTextFileBusy(t *testing.T) {
+	t.Parallel()
+	// Opening the running test binary for writing fails with ETXTBSY
+	exe, err := os.Executable()
+	require.NoError(t, err)
+	fh, err := os.OpenFile(exe, os.O_WRONLY, 0)
+	if err == nil {
+		_ = fh.Close()
+		t.Skip("file system does not report ETXTBSY")
+	}
+	assert.True(t, file.IsTextFileBusy(err))
+	assert.False(t, file.IsTextFileBusy(os.ErrNotExist))
+}
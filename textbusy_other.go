@@ -0,0 +1,8 @@
+//go:build !(unix || windows || wasip1)
+
+package file
+
+// IsTextFileBusy always returns false as the platform has no ETXTBSY.
+func IsTextFileBusy(error) bool {
+	return false
+}
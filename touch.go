@@ -0,0 +1,34 @@
+package file
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Touch creates an empty file at filePath and its parent directories like
+// NewWriter, or sets the modification time of an existing file to now without
+// changing its content.
+func Touch(filePath string, opts ...Option) error {
+	cfg := newConfig(opts)
+	filePath, err := cfg.expandPath(filePath)
+	if err != nil {
+		return err
+	}
+	if err := cfg.mkdirParent(filePath); err != nil {
+		return err
+	}
+	fh, err := cfg.openFile(filePath, os.O_WRONLY|os.O_CREATE, cfg.filePerm())
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrCreateFile, err)
+	}
+	if err := cfg.chmod(fh); err != nil {
+		return errors.Join(err, fh.Close())
+	}
+	if err := fh.Close(); err != nil {
+		return err
+	}
+	now := time.Now()
+	return cfg.chtimes(filePath, now, now)
+}
@@ -0,0 +1,40 @@
+package file_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTouch(t *testing.T) {
+	t.Parallel()
+	testFilePath := filepath.Join(t.TempDir(), "build", ".done")
+
+	require.NoError(t, file.Touch(testFilePath))
+
+	info, err := os.Stat(testFilePath)
+	require.NoError(t, err)
+	assert.Zero(t, info.Size())
+}
+
+func TestTouchExisting(t *testing.T) {
+	t.Parallel()
+	testFilePath := createFile(t, "Hello, World!")
+	old := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(testFilePath, old, old))
+
+	require.NoError(t, file.Touch(testFilePath))
+
+	info, err := os.Stat(testFilePath)
+	require.NoError(t, err)
+	assert.True(t, info.ModTime().After(old), info.ModTime())
+	cnt, err := os.ReadFile(testFilePath)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, World!", string(cnt))
+}
@@ -0,0 +1,36 @@
+package file
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Truncate changes the size of the file. An open writer that supports it, like
+// the file created by NewWriter, is truncated directly. Other open writers, e.g.
+// an in-memory writer or one that compresses or buffers the data on the way to
+// the file, fail with errors.ErrUnsupported. Without an open writer the file at
+// the path is truncated.
+func (f *File) Truncate(size int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Writer != nil {
+		t, ok := f.Writer.Writer.(interface{ Truncate(size int64) error })
+		if !ok {
+			return fmt.Errorf("failed to truncate writer of type %T: %w", f.Writer.Writer, errors.ErrUnsupported)
+		}
+		return t.Truncate(size)
+	}
+	filePath := f.path()
+	if filePath == "" {
+		return ErrNoPath
+	}
+	fh, err := f.config().openFile(filePath, os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	if err := fh.Truncate(size); err != nil {
+		return errors.Join(err, fh.Close())
+	}
+	return fh.Close()
+}
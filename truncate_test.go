@@ -0,0 +1,64 @@
+package file_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTruncate(t *testing.T) {
+	t.Parallel()
+	f := file.NewWriter(filepath.Join(t.TempDir(), "output.log"))
+	_, err := f.Write([]byte("0123456789"))
+	require.NoError(t, err)
+
+	require.NoError(t, f.Truncate(4))
+
+	cnt, err := f.Read()
+	require.NoError(t, err)
+	assert.Equal(t, "0123", string(cnt))
+	require.NoError(t, f.Close())
+}
+
+func TestTruncatePath(t *testing.T) {
+	t.Parallel()
+	filePath := createFile(t, "0123456789")
+
+	require.NoError(t, file.New(filePath).Truncate(4))
+
+	cnt, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, "0123", string(cnt))
+}
+
+func TestTruncateError(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	f := file.NewWriterBuffer(&buf, "")
+	_, err := f.Write([]byte("0123456789"))
+	require.NoError(t, err)
+	err = f.Truncate(4)
+	require.ErrorIs(t, err, errors.ErrUnsupported)
+	assert.ErrorContains(t, err, "*bytes.Buffer")
+
+	// Truncating the file below the compressor would corrupt the stream
+	gz := file.NewGzipWriter(filepath.Join(t.TempDir(), "output.log.gz"))
+	_, err = gz.Write([]byte("0123456789"))
+	require.NoError(t, err)
+	require.ErrorIs(t, gz.Truncate(4), errors.ErrUnsupported)
+	require.NoError(t, gz.Close())
+
+	err = file.NewReader(strings.NewReader("0123456789")).Truncate(4)
+	require.ErrorIs(t, err, file.ErrNoPath)
+
+	err = file.New("nonexistent.txt").Truncate(4)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,38 @@
+package file
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Update reads the file, passes its content to fn and atomically replaces the
+// file with the result. A missing file is passed as empty content. If fn fails
+// the file is left untouched. Concurrent updates, also from other processes, are
+// serialized by an advisory lock on a lock file next to the file named after it
+// with a ".lock" suffix, so no update gets lost.
+func (f *File) Update(fn func(old []byte) ([]byte, error)) (err error) {
+	if f.FilePath == "" {
+		return ErrNoPath
+	}
+	cfg := f.config()
+	if err := cfg.mkdirParent(f.FilePath); err != nil {
+		return err
+	}
+	unlock, err := cfg.lockFile(f.FilePath + ".lock")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, unlock())
+	}()
+	old, err := cfg.readFile(f.FilePath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read file %q: %w", f.FilePath, err)
+	}
+	updated, err := fn(old)
+	if err != nil {
+		return fmt.Errorf("failed to update file %q: %w", f.FilePath, err)
+	}
+	return cfg.writeAtomic(f.FilePath, updated, nil)
+}
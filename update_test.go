@@ -0,0 +1,100 @@
+package file_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func increment(old []byte) ([]byte, error) {
+	n := 0
+	if len(old) > 0 {
+		var err error
+		if n, err = strconv.Atoi(string(old)); err != nil {
+			return nil, err
+		}
+	}
+	return []byte(strconv.Itoa(n + 1)), nil
+}
+
+// @markdown
+// TestUpdate illustrates how to safely read, transform and write back a file from concurrent updaters.
+func TestUpdate(t *testing.T) {
+	t.Parallel()
+	filePath := filepath.Join(t.TempDir(), "counter.txt")
+
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Go(func() {
+			assert.NoError(t, file.New(filePath).Update(increment))
+		})
+	}
+	wg.Wait()
+
+	cnt, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, "50", string(cnt))
+}
+
+func TestUpdateKeepsPermissions(t *testing.T) {
+	t.Parallel()
+	filePath := createFile(t, "1")
+	require.NoError(t, os.Chmod(filePath, 0o640))
+
+	require.NoError(t, file.New(filePath).Update(increment))
+
+	info, err := os.Stat(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o640), info.Mode().Perm())
+}
+
+func TestUpdateError(t *testing.T) {
+	t.Parallel()
+	filePath := createFile(t, "not a number")
+
+	err := file.New(filePath).Update(increment)
+	require.ErrorIs(t, err, strconv.ErrSyntax)
+
+	cnt, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, "not a number", string(cnt))
+
+	err = file.New(filePath).Update(func([]byte) ([]byte, error) {
+		return nil, errors.ErrUnsupported
+	})
+	require.ErrorIs(t, err, errors.ErrUnsupported)
+
+	err = file.NewReader(strings.NewReader("1")).Update(increment)
+	require.ErrorIs(t, err, file.ErrNoPath)
+}
+
+func TestUpdateInRoot(t *testing.T) {
+	t.Parallel()
+	parent := t.TempDir()
+	dir := filepath.Join(parent, "root")
+	require.NoError(t, os.Mkdir(dir, 0o755))
+	root, err := os.OpenRoot(dir)
+	require.NoError(t, err)
+	defer root.Close()
+
+	require.NoError(t, file.NewInRoot(root, "data/counter.txt").Update(increment))
+	cnt, err := os.ReadFile(filepath.Join(dir, "data", "counter.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "1", string(cnt))
+
+	// Neither the file nor its lock file may escape the root
+	err = file.NewInRoot(root, "../counter.txt").Update(increment)
+	require.Error(t, err)
+	entries, err := os.ReadDir(parent)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
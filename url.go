@@ -0,0 +1,43 @@
+package file
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// NewReaderURL returns a File that reads the content of url over HTTP(S). The
+// request is sent on first read, responses with a non-2xx status fail the read.
+// Close closes the response body.
+func NewReaderURL(url string) *File {
+	return NewReaderURLContext(context.Background(), url)
+}
+
+// NewReaderURLContext is like NewReaderURL but sends the request with ctx, so
+// cancelling ctx aborts a pending request as well as an in-flight read of the
+// response body.
+func NewReaderURLContext(ctx context.Context, url string) *File {
+	return &File{
+		reader: sync.OnceValues(urlReaderFunc(ctx, url)),
+	}
+}
+
+func urlReaderFunc(ctx context.Context, url string) func() (io.Reader, error) {
+	return func() (io.Reader, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request for %q: %w", url, err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %q: %w", url, err)
+		}
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return nil, errors.Join(fmt.Errorf("failed to get %q: unexpected status %s", url, resp.Status), resp.Body.Close())
+		}
+		return resp.Body, nil
+	}
+}
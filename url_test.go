@@ -0,0 +1,86 @@
+package file_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// @markdown
+// TestNewReaderURL illustrates how to read remote content with the same File API.
+func TestNewReaderURL(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, err := w.Write([]byte("Hello, World!"))
+		assert.NoError(t, err)
+	}))
+	t.Cleanup(srv.Close)
+
+	f := file.NewReaderURL(srv.URL)
+	cnt, err := f.Read()
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, World!", string(cnt))
+	require.NoError(t, f.Close())
+}
+
+func TestNewReaderURLNotFound(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(srv.Close)
+
+	f := file.NewReaderURL(srv.URL + "/missing")
+	_, err := f.Read()
+	require.ErrorContains(t, err, "404 Not Found")
+	require.NoError(t, f.Close())
+}
+
+func TestNewReaderURLInvalid(t *testing.T) {
+	t.Parallel()
+	f := file.NewReaderURL("://invalid")
+	_, err := f.Read()
+	require.ErrorContains(t, err, "failed to create request")
+}
+
+func TestNewReaderURLContextCancel(t *testing.T) {
+	t.Parallel()
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, err := w.Write([]byte("partial"))
+		assert.NoError(t, err)
+		assert.NoError(t, http.NewResponseController(w).Flush())
+		<-release
+	}))
+	t.Cleanup(srv.Close)
+	t.Cleanup(func() { close(release) })
+
+	ctx, cancel := context.WithCancel(t.Context())
+	f := file.NewReaderURLContext(ctx, srv.URL)
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	// The response body never ends, so only the cancellation stops the read
+	start := time.Now()
+	_, err := f.Read()
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, time.Since(start), 5*time.Second)
+	require.NoError(t, f.Close())
+}
+
+func TestNewReaderURLContextCanceled(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(srv.Close)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	f := file.NewReaderURLContext(ctx, srv.URL)
+	_, err := f.Read()
+	require.ErrorIs(t, err, context.Canceled)
+	require.NoError(t, f.Close())
+}
@@ -0,0 +1,133 @@
+package file
+
+import (
+	"bufio"
+	"compress/gzip"
+	"crypto/md5"  //nolint:gosec // md5 is offered for compatibility with published checksums
+	"crypto/sha1" //nolint:gosec // sha1 is offered for compatibility with published checksums
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// checksumHashes are the hash algorithms that can be selected by name.
+var checksumHashes = map[string]func() hash.Hash{
+	"md5":    md5.New,
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+}
+
+// openWriter creates the file a Writer writes to.
+func (c *config) openWriter(filePath string) (io.WriteCloser, error) {
+	switch {
+	case c.fsys != nil:
+		return c.fsys.Create(filePath)
+	case c.atomic:
+		a, err := newAtomicFile(filePath, c)
+		if err != nil {
+			return nil, err
+		}
+		c.atomicTemp = a
+		return a, nil
+	case c.rotating:
+		return newRotatingFile(filePath, c)
+	}
+	if c.backup {
+		if err := c.backupFile(filePath); err != nil {
+			return nil, err
+		}
+	}
+	var fh *os.File
+	var err error
+	switch {
+	case c.appendMode:
+		fh, err = c.openFile(filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, c.filePerm())
+	case c.randomAccess:
+		fh, err = c.openFile(filePath, os.O_RDWR|os.O_CREATE, c.filePerm())
+	default:
+		fh, err = c.create(filePath)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := c.chmod(fh); err != nil {
+		return nil, errors.Join(err, fh.Close())
+	}
+	if c.locked {
+		return newLockedFile(fh)
+	}
+	return fh, nil
+}
+
+// chmod sets the mode of WithFileMode on fh, so it is exact regardless of the
+// umask and also applies to already existing files.
+func (c *config) chmod(fh *os.File) error {
+	if c.fileMode == 0 {
+		return nil
+	}
+	if err := fh.Chmod(c.fileMode); err != nil {
+		return fmt.Errorf("failed to chmod %q: %w", fh.Name(), err)
+	}
+	return nil
+}
+
+// discard closes a created file that is not going to be used. An atomic writer
+// removes its temp file instead of replacing the target.
+func discard(w io.WriteCloser) error {
+	if a, ok := w.(*atomicFile); ok {
+		return a.abort()
+	}
+	return w.Close()
+}
+
+// wrapWriter layers the configured writer features on top of the created file.
+func (c *config) wrapWriter(filePath string, w io.WriteCloser) (io.Writer, error) {
+	if c.limited {
+		w = &limitedWriter{WriteCloser: w, remaining: c.quota}
+	}
+	if c.sidecarAlgo != "" {
+		newHash, ok := checksumHashes[c.sidecarAlgo]
+		if !ok {
+			return nil, fmt.Errorf("unsupported checksum algorithm %q", c.sidecarAlgo)
+		}
+		w = &sidecarWriter{WriteCloser: w, cfg: c, hash: newHash(), filePath: filePath, algo: c.sidecarAlgo}
+	}
+	if c.hash != nil {
+		w = &hashWriter{WriteCloser: w, hash: c.hash}
+	}
+	if c.compress {
+		gz, err := gzip.NewWriterLevel(w, c.gzipLevel)
+		if err != nil {
+			return nil, err
+		}
+		w = &gzipWriter{Writer: gz, dst: w}
+	}
+	if c.zstdCompress {
+		zw, err := zstd.NewWriter(w, zstd.WithEncoderLevel(c.zstdLevel))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create zstd writer: %w", err)
+		}
+		w = &zstdWriter{Encoder: zw, dst: w}
+	}
+	if c.tailSize > 0 {
+		c.tail = newRingBuffer(c.tailSize)
+		w = &tailWriter{WriteCloser: w, ring: c.tail}
+	}
+	if c.chunkSize > 0 {
+		w = &chunkedWriter{WriteCloser: w, size: c.chunkSize}
+	}
+	if c.bufSize > 0 {
+		w = &bufferedWriter{Writer: bufio.NewWriterSize(w, c.bufSize), dst: w}
+	}
+	if c.recordSize > 0 {
+		w = &paddingWriter{WriteCloser: w, size: c.recordSize, pad: c.recordPad, truncate: c.truncateRecords}
+	}
+	return w, nil
+}
@@ -0,0 +1,82 @@
+package file
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+)
+
+// ZipReader exposes the entries of a zip archive as Files, see NewZipReader.
+type ZipReader struct {
+	filePath string
+	zr       *zip.ReadCloser
+}
+
+// NewZipReader returns a ZipReader for the zip archive at filePath, which is
+// opened by the first call of Files.
+func NewZipReader(filePath string) *ZipReader {
+	return &ZipReader{filePath: filePath}
+}
+
+// Files returns a File for every regular entry of the archive in archive order,
+// directory entries are skipped. The FilePath of a File is the name of its entry
+// and Read, Stat and the other reading methods work on the entry. Writing to them
+// fails with errors.ErrUnsupported. The Files can be read until the ZipReader is
+// closed.
+func (z *ZipReader) Files() ([]*File, error) {
+	if z.zr == nil {
+		zr, err := zip.OpenReader(z.filePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open zip %q: %w", z.filePath, err)
+		}
+		z.zr = zr
+	}
+	fsys := zipFS{}
+	files := make([]*File, 0, len(z.zr.File))
+	for _, zf := range z.zr.File {
+		if zf.FileInfo().IsDir() {
+			continue
+		}
+		fsys[zf.Name] = zf
+		files = append(files, New(zf.Name, WithFS(fsys)))
+	}
+	return files, nil
+}
+
+// Close closes the archive.
+func (z *ZipReader) Close() error {
+	if z.zr == nil {
+		return nil
+	}
+	return z.zr.Close()
+}
+
+// zipFS is the read-only FileSystem of the entries of a zip archive by name.
+type zipFS map[string]*zip.File
+
+func (z zipFS) Open(name string) (io.ReadCloser, error) {
+	zf, ok := z[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return zf.Open()
+}
+
+func (z zipFS) Create(name string) (io.WriteCloser, error) {
+	return nil, &fs.PathError{Op: "create", Path: name, Err: errors.ErrUnsupported}
+}
+
+func (z zipFS) MkdirAll(path string, _ os.FileMode) error {
+	return &fs.PathError{Op: "mkdir", Path: path, Err: errors.ErrUnsupported}
+}
+
+func (z zipFS) Stat(name string) (os.FileInfo, error) {
+	zf, ok := z[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+	}
+	return zf.FileInfo(), nil
+}
@@ -0,0 +1,75 @@
+package file_test
+
+import (
+	"archive/zip"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createZipFile(t *testing.T, entries map[string]string) string {
+	t.Helper()
+	filePath := filepath.Join(t.TempDir(), "upload.zip")
+	fh, err := os.Create(filePath)
+	require.NoError(t, err)
+	zw := zip.NewWriter(fh)
+	_, err = zw.Create("docs/")
+	require.NoError(t, err)
+	for name, cnt := range entries {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(cnt))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	require.NoError(t, fh.Close())
+	return filePath
+}
+
+// @markdown
+// TestNewZipReader illustrates how to read the entries of a zip archive as Files.
+func TestNewZipReader(t *testing.T) {
+	t.Parallel()
+	filePath := createZipFile(t, map[string]string{"docs/README.md": "# Upload", "data.csv": "a,b\n1,2\n"})
+
+	zr := file.NewZipReader(filePath)
+	files, err := zr.Files()
+	require.NoError(t, err)
+
+	// The directory entry is skipped
+	entries := map[string]string{}
+	for _, f := range files {
+		cnt, err := f.Read()
+		require.NoError(t, err)
+		entries[f.FilePath] = string(cnt)
+		size, err := f.Size()
+		require.NoError(t, err)
+		assert.Equal(t, int64(len(cnt)), size)
+		require.NoError(t, f.Close())
+	}
+	assert.Equal(t, map[string]string{"docs/README.md": "# Upload", "data.csv": "a,b\n1,2\n"}, entries)
+	require.NoError(t, zr.Close())
+}
+
+func TestNewZipReaderError(t *testing.T) {
+	t.Parallel()
+	_, err := file.NewZipReader("nonexistent.zip").Files()
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	_, err = file.NewZipReader(createFile(t, "not a zip")).Files()
+	require.ErrorIs(t, err, zip.ErrFormat)
+
+	zr := file.NewZipReader(createZipFile(t, map[string]string{"data.csv": "a,b\n"}))
+	files, err := zr.Files()
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	_, err = files[0].Write([]byte("c,d\n"))
+	require.ErrorIs(t, err, errors.ErrUnsupported)
+	require.NoError(t, zr.Close())
+}
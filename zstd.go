@@ -0,0 +1,55 @@
+package file
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// NewZstdReader returns a File like New that decompresses the zstd compressed
+// content of filePath while reading.
+func NewZstdReader(filePath string, opts ...Option) *File {
+	cfg := newConfig(opts)
+	cfg.zstdDecompress = true
+	return cfg.newFile(filePath)
+}
+
+// NewZstdWriter returns a File like NewWriter whose writes are zstd compressed
+// on the way to filePath. The compressed stream is only complete once Close
+// returned. Use WithZstdLevel to change the default compression level.
+func NewZstdWriter(filePath string, opts ...Option) *File {
+	cfg := newConfig(append([]Option{WithZstdLevel(zstd.SpeedDefault)}, opts...))
+	cfg.zstdCompress = true
+	return cfg.newFile(filePath)
+}
+
+// zstdWriter compresses into dst. Close flushes the last frame before closing
+// dst.
+type zstdWriter struct {
+	*zstd.Encoder
+	dst io.WriteCloser
+}
+
+func (z *zstdWriter) Close() error {
+	if err := z.Encoder.Close(); err != nil {
+		return errors.Join(fmt.Errorf("failed to close zstd stream: %w", err), z.dst.Close())
+	}
+	return z.dst.Close()
+}
+
+func (z *zstdWriter) Unwrap() io.Writer {
+	return z.dst
+}
+
+// zstdReader decompresses src and releases both on Close.
+type zstdReader struct {
+	*zstd.Decoder
+	src io.Reader
+}
+
+func (z *zstdReader) Close() error {
+	z.Decoder.Close()
+	return closeIfCloser(z.src)
+}
@@ -0,0 +1,67 @@
+package file_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fr12k/go-file"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// @markdown
+// TestNewZstdWriter illustrates how to write and read back a zstd compressed file.
+func TestNewZstdWriter(t *testing.T) {
+	t.Parallel()
+	filePath := filepath.Join(t.TempDir(), "logs", "app.log.zst")
+	content := strings.Repeat("Hello, World!\n", 100)
+
+	w := file.NewZstdWriter(filePath, file.WithZstdLevel(zstd.SpeedBestCompression))
+	_, err := w.WriteString(content)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	info, err := os.Stat(filePath)
+	require.NoError(t, err)
+	assert.Less(t, info.Size(), int64(len(content)))
+
+	r := file.NewZstdReader(filePath)
+	cnt, err := r.Read()
+	require.NoError(t, err)
+	assert.Equal(t, content, string(cnt))
+	require.NoError(t, r.Close())
+}
+
+func TestNewZstdReader(t *testing.T) {
+	t.Parallel()
+	enc, err := zstd.NewWriter(nil)
+	require.NoError(t, err)
+	filePath := filepath.Join(t.TempDir(), "data.zst")
+	require.NoError(t, os.WriteFile(filePath, enc.EncodeAll([]byte("Hello, World!"), nil), 0o600))
+	require.NoError(t, enc.Close())
+
+	f := file.NewZstdReader(filePath)
+	cnt, err := f.Read()
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, World!", string(cnt))
+	require.NoError(t, f.Close())
+}
+
+func TestNewZstdReaderError(t *testing.T) {
+	t.Parallel()
+	_, err := file.NewZstdReader(createFile(t, "this is not a zstd file")).Read()
+	require.ErrorIs(t, err, zstd.ErrMagicMismatch)
+
+	_, err = file.NewZstdReader("nonexistent.zst").Read()
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestNewZstdWriterError(t *testing.T) {
+	t.Parallel()
+	_, err := file.NewZstdWriter(filepath.Join(t.TempDir(), "out.zst"), file.WithZstdLevel(0)).Write([]byte("x"))
+	require.ErrorContains(t, err, "failed to create zstd writer")
+}